@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/eval-hub/eval-hub/cmd/eval_hub/server"
+	"github.com/eval-hub/eval-hub/internal/callbacks"
 	"github.com/eval-hub/eval-hub/internal/config"
 	"github.com/eval-hub/eval-hub/internal/logging"
 	"github.com/eval-hub/eval-hub/internal/mlflow"
@@ -56,6 +57,9 @@ func main() {
 		// we do this as no point trying to continue
 		startUpFailed(serviceConfig, err, "Failed to create storage", logger)
 	}
+	// notify the callback URLs of the evaluation jobs once they finish
+	notifier := callbacks.NewNotifier(logger, serviceConfig.Callbacks, nil, nil)
+	storage = callbacks.NewStorage(storage, notifier, logger)
 
 	// set up the provider configs
 	providerConfigs, err := config.LoadProviderConfigs(logger)
@@ -74,7 +78,7 @@ func main() {
 
 	mlflowClient := mlflow.NewMLFlowClient()
 
-	srv, err := server.NewServer(logger, serviceConfig, providerConfigs, storage, validate, runtime, mlflowClient)
+	srv, err := server.NewServer(logger, serviceConfig, providerConfigs, storage, validate, runtime, mlflowClient, notifier)
 	if err != nil {
 		// we do this as no point trying to continue
 		startUpFailed(serviceConfig, err, "Failed to create server", logger)
@@ -111,17 +115,12 @@ func main() {
 
 	logger.Info("Shutting down server...")
 
-	// shutdown the storage
-	if err := storage.Close(); err != nil {
-		logger.Error("Failed to close storage", "error", err.Error(), "storage", storage.GetDatasourceName())
-	}
-
 	// Create a context with timeout for graceful shutdown
 	waitForShutdown := 30 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), waitForShutdown)
 	defer cancel()
 
-	// shutdown the logger
+	// drain the runtime and the requests in progress, the storage is closed last
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("Server forced to shutdown", "error", err.Error(), "timeout", waitForShutdown)
 		_ = logShutdown() // ignore the error
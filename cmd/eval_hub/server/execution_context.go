@@ -3,26 +3,35 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
 	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/http_wrappers"
 	"github.com/eval-hub/eval-hub/internal/logging"
 	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
+// defaultRequestTimeout bounds the storage and runtime calls of a request when no
+// service.request_timeout is configured.
+const defaultRequestTimeout = 60 * time.Second
+
+// defaultMaxRequestBodyBytes caps a request body when no service.max_request_body_bytes is configured.
+const defaultMaxRequestBodyBytes = 1 << 20
+
 // newExecutionContext creates a new ExecutionContext with default values. This function
 // is called at the route level before invoking evaluation-related handlers to set up
 // request-scoped context.
 //
 // The function automatically:
 //   - Enhances the logger with request-specific fields via logging.LoggerWithRequest
-//   - Sets default timeout (60 minutes) and retry attempts (3)
-//   - Initializes an empty metadata map
+//   - Derives the context from the request, bounded by the configured request timeout
 //
 // This enables automatic request ID tracking (from X-Global-Transaction-Id header or
 // auto-generated UUID) and structured logging with consistent request metadata.
@@ -38,20 +47,52 @@ func (s *Server) newExecutionContext(r *http.Request) *executioncontext.Executio
 	requestID, enhancedLogger := s.loggerWithRequest(r)
 
 	return executioncontext.NewExecutionContext(
-		context.Background(),
+		r.Context(),
 		requestID,
 		enhancedLogger,
-		3)
+		s.requestTimeout())
+}
+
+// newRequestWrapper wraps the request with the body limit of the route it matched.
+func (s *Server) newRequestWrapper(r *http.Request) http_wrappers.RequestWrapper {
+	return NewRequestWrapper(r, s.maxRequestBodyBytes(r.Pattern))
+}
+
+// maxRequestBodyBytes returns the body limit of the route pattern, the service wide limit
+// when the route has none and defaultMaxRequestBodyBytes when neither is configured.
+func (s *Server) maxRequestBodyBytes(pattern string) int64 {
+	if s.serviceConfig == nil || s.serviceConfig.Service == nil {
+		return defaultMaxRequestBodyBytes
+	}
+	if limit, ok := s.serviceConfig.Service.RequestBodyLimits[pattern]; ok && limit > 0 {
+		return limit
+	}
+	if s.serviceConfig.Service.MaxRequestBodyBytes > 0 {
+		return s.serviceConfig.Service.MaxRequestBodyBytes
+	}
+	return defaultMaxRequestBodyBytes
+}
+
+// requestTimeout returns the configured request timeout, defaultRequestTimeout when unset.
+func (s *Server) requestTimeout() time.Duration {
+	if s.serviceConfig != nil && s.serviceConfig.Service != nil && s.serviceConfig.Service.RequestTimeout > 0 {
+		return s.serviceConfig.Service.RequestTimeout
+	}
+	return defaultRequestTimeout
 }
 
 // Abstract request objects to not depende on the underlying http framework.
 type ReqWrapper struct {
 	Request *http.Request
+	// maxBodyBytes caps the body read by BodyAsBytes, the body is not capped when it is 0
+	maxBodyBytes int64
 }
 
-func NewRequestWrapper(req *http.Request) http_wrappers.RequestWrapper {
+// NewRequestWrapper wraps the request, a body larger than maxBodyBytes is refused with a 413.
+func NewRequestWrapper(req *http.Request, maxBodyBytes int64) http_wrappers.RequestWrapper {
 	return &ReqWrapper{
-		Request: req,
+		Request:      req,
+		maxBodyBytes: maxBodyBytes,
 	}
 }
 
@@ -79,9 +120,22 @@ func (r *ReqWrapper) Header(key string) string {
 	return r.Request.Header.Get(key)
 }
 
+// BodyAsBytes reads the body, a body over the limit is refused from its Content-Length
+// without being read, and otherwise once one byte more than the limit was read.
 func (r *ReqWrapper) BodyAsBytes() ([]byte, error) {
-	bodyBytes, err := io.ReadAll(r.Request.Body)
+	body := r.Request.Body
+	if r.maxBodyBytes > 0 {
+		if r.Request.ContentLength > r.maxBodyBytes {
+			return nil, serviceerrors.NewServiceError(messages.RequestBodyTooLarge, "Limit", r.maxBodyBytes)
+		}
+		body = http.MaxBytesReader(nil, body, r.maxBodyBytes)
+	}
+	bodyBytes, err := io.ReadAll(body)
 	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, serviceerrors.NewServiceError(messages.RequestBodyTooLarge, "Limit", r.maxBodyBytes)
+		}
 		return nil, err
 	}
 
@@ -96,6 +150,10 @@ func (r *ReqWrapper) PathValue(name string) string {
 	return r.Request.PathValue(name)
 }
 
+func (r *ReqWrapper) Context() context.Context {
+	return r.Request.Context()
+}
+
 type RespWrapper struct {
 	Response http.ResponseWriter
 	ctx      *executioncontext.ExecutionContext
@@ -120,6 +178,11 @@ func (r RespWrapper) Write(buf []byte) (int, error) {
 	return r.Response.Write(buf)
 }
 
+func (r RespWrapper) Flush() {
+	// not every writer supports flushing, the data is then sent when the handler returns
+	_ = http.NewResponseController(r.Response).Flush()
+}
+
 func (r RespWrapper) WriteJSON(v any, code int) {
 	r.SetHeader("Content-Type", "application/json")
 	r.SetStatusCode(code)
@@ -0,0 +1,100 @@
+package server_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/cmd/eval_hub/server"
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+)
+
+// countingReader returns size bytes and counts how many of them were read.
+type countingReader struct {
+	size int64
+	read int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	if r.read >= r.size {
+		return 0, io.EOF
+	}
+	n := int64(len(p))
+	if remaining := r.size - r.read; n > remaining {
+		n = remaining
+	}
+	for i := range n {
+		p[i] = 'a'
+	}
+	r.read += n
+	return int(n), nil
+}
+
+func expectBodyTooLarge(t *testing.T, err error) {
+	t.Helper()
+	var serviceErr abstractions.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.MessageCode().GetCode() != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected a %d error, got %v", http.StatusRequestEntityTooLarge, err)
+	}
+}
+
+func TestBodyAsBytesRefusesBodyOverLimit(t *testing.T) {
+	const limit = 1024
+
+	t.Run("declared length is refused without reading", func(t *testing.T) {
+		body := &countingReader{size: limit + 1}
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/evaluations/jobs", body)
+		req.ContentLength = limit + 1
+		_, err := server.NewRequestWrapper(req, limit).BodyAsBytes()
+		expectBodyTooLarge(t, err)
+		if body.read != 0 {
+			t.Fatalf("expected the body not to be read, read %d bytes", body.read)
+		}
+	})
+
+	t.Run("streamed body is read up to the limit", func(t *testing.T) {
+		body := &countingReader{size: 100 * limit}
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/evaluations/jobs", body)
+		req.ContentLength = -1
+		_, err := server.NewRequestWrapper(req, limit).BodyAsBytes()
+		expectBodyTooLarge(t, err)
+		if body.read > limit+1 {
+			t.Fatalf("expected at most %d bytes to be read, read %d", limit+1, body.read)
+		}
+	})
+
+	t.Run("body at the limit is accepted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/evaluations/jobs", strings.NewReader(strings.Repeat("a", limit)))
+		bodyBytes, err := server.NewRequestWrapper(req, limit).BodyAsBytes()
+		if err != nil || len(bodyBytes) != limit {
+			t.Fatalf("expected the body to be read, got %d bytes and %v", len(bodyBytes), err)
+		}
+	})
+}
+
+func TestCreateEndpointsRefuseBodyOverDefaultLimit(t *testing.T) {
+	srv, err := createServer(0)
+	if err != nil {
+		t.Fatalf("NewServer() returned error: %v", err)
+	}
+	handler, err := srv.SetupRoutes()
+	if err != nil {
+		t.Fatalf("SetupRoutes() returned error: %v", err)
+	}
+	body := strings.Repeat(" ", 1<<20+1)
+	for _, request := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/evaluations/jobs"},
+		{http.MethodPost, "/api/v1/evaluations/collections"},
+		{http.MethodPut, "/api/v1/evaluations/collections/test-collection"},
+	} {
+		req := httptest.NewRequest(request.method, request.path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected status %d for %s %s, got %d", http.StatusRequestEntityTooLarge, request.method, request.path, w.Code)
+		}
+	}
+}
@@ -2,14 +2,18 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/callbacks"
 	"github.com/eval-hub/eval-hub/internal/config"
 	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/handlers"
 	"github.com/eval-hub/eval-hub/internal/messages"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -30,6 +34,9 @@ type Server struct {
 	validate        *validator.Validate
 	runtime         abstractions.Runtime
 	mlflowClient    *mlflowclient.Client
+	notifier        *callbacks.Notifier
+	// shuttingDown is set once Shutdown started, new evaluation jobs are refused from then on
+	shuttingDown atomic.Bool
 }
 
 // NewServer creates a new HTTP server instance with the provided logger and configuration.
@@ -47,6 +54,7 @@ type Server struct {
 // Parameters:
 //   - logger: The structured logger for the server
 //   - serviceConfig: The service configuration containing port and other settings
+//   - notifier: The notifier of the job callbacks that Shutdown waits for, optional
 //
 // Returns:
 //   - *Server: A configured server instance
@@ -58,6 +66,7 @@ func NewServer(logger *slog.Logger,
 	validate *validator.Validate,
 	runtime abstractions.Runtime,
 	mlflowClient *mlflowclient.Client,
+	notifier *callbacks.Notifier,
 ) (*Server, error) {
 
 	if logger == nil {
@@ -82,6 +91,7 @@ func NewServer(logger *slog.Logger,
 		validate:        validate,
 		runtime:         runtime,
 		mlflowClient:    mlflowClient,
+		notifier:        notifier,
 	}, nil
 }
 
@@ -175,7 +185,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc("/api/v1/health", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch req.Method() {
 		case http.MethodGet:
 			h.HandleHealth(ctx, req, resp)
@@ -185,13 +195,40 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 
 	})
 
+	// Probes, /healthz answers as long as the process is up, /readyz checks the dependencies
+	router.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch req.Method() {
+		case http.MethodGet:
+			h.HandleHealth(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	router.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch req.Method() {
+		case http.MethodGet:
+			h.HandleReadiness(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
 	// Evaluation jobs endpoints
 	router.HandleFunc("/api/v1/evaluations/jobs", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := &ReqWrapper{Request: r}
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodPost:
+			if s.refuseJobs(ctx, resp) {
+				return
+			}
 			h.HandleCreateEvaluation(ctx, req, resp)
 		case http.MethodGet:
 			h.HandleListEvaluations(ctx, req, resp)
@@ -204,7 +241,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/events", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodPost:
 			h.HandleUpdateEvaluation(ctx, req, resp)
@@ -213,11 +250,81 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 		}
 	})
 
+	// Handle benchmark logs endpoint
+	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}/logs", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodGet:
+			// followed logs outlive the write timeout of the server, the stream ends with the request instead
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+			h.HandleGetBenchmarkLogs(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	// Handle single benchmark endpoint
+	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodDelete:
+			h.HandleCancelBenchmark(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	// Handle evaluation retry endpoint
+	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/retry", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodPost:
+			if s.refuseJobs(ctx, resp) {
+				return
+			}
+			h.HandleRetryEvaluation(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	// Handle evaluation summary endpoint
+	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/summary", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetEvaluationSummary(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	// Handle evaluation artifacts endpoint
+	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/artifacts", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListEvaluationArtifacts(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
 	// Handle individual job endpoints
 	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/jobs/{%s}", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodGet:
 			h.HandleGetEvaluation(ctx, req, resp)
@@ -232,7 +339,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc("/api/v1/evaluations/benchmarks", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodGet:
 			h.HandleListBenchmarks(ctx, req, resp)
@@ -245,12 +352,12 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc("/api/v1/evaluations/collections", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodPost:
-			h.HandleCreateCollection(ctx, resp)
+			h.HandleCreateCollection(ctx, req, resp)
 		case http.MethodGet:
-			h.HandleListCollections(ctx, resp)
+			h.HandleListCollections(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
@@ -259,16 +366,31 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/collections/{%s}", constants.PATH_PARAMETER_COLLECTION_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleGetCollection(ctx, resp)
+			h.HandleGetCollection(ctx, req, resp)
 		case http.MethodPut:
-			h.HandleUpdateCollection(ctx, resp)
+			h.HandleUpdateCollection(ctx, req, resp)
 		case http.MethodPatch:
-			h.HandlePatchCollection(ctx, resp)
+			h.HandlePatchCollection(ctx, req, resp)
 		case http.MethodDelete:
-			h.HandleDeleteCollection(ctx, resp)
+			h.HandleDeleteCollection(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	router.HandleFunc(fmt.Sprintf("/api/v1/evaluations/collections/{%s}/run", constants.PATH_PARAMETER_COLLECTION_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodPost:
+			if s.refuseJobs(ctx, resp) {
+				return
+			}
+			h.HandleRunCollection(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
@@ -278,7 +400,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc("/api/v1/evaluations/providers", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodGet:
 			h.HandleListProviders(ctx, req, resp)
@@ -291,7 +413,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodGet:
 			h.HandleOpenAPI(ctx, req, resp)
@@ -303,7 +425,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	router.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
-		req := NewRequestWrapper(r)
+		req := s.newRequestWrapper(r)
 		switch r.Method {
 		case http.MethodGet:
 			h.HandleDocs(ctx, req, resp)
@@ -312,6 +434,19 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 		}
 	})
 
+	// System metrics endpoint
+	router.HandleFunc("/api/v1/metrics/system", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(r)
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetSystemMetrics(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
 	// Prometheus metrics endpoint
 	router.Handle("/metrics", promhttp.Handler())
 
@@ -361,9 +496,48 @@ func (s *Server) Start() error {
 	return err
 }
 
+// Shutdown stops the service gracefully, everything shares the deadline of the context:
+//   - new evaluation jobs are refused with a 503
+//   - the runtime stops its watchers and waits for the benchmark submissions in progress
+//   - the HTTP server waits for the requests in progress
+//   - the storage is closed last, as the steps before may still update jobs
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down server gracefully...")
-	return s.httpServer.Shutdown(ctx)
+	s.shuttingDown.Store(true)
+
+	var errs []error
+	if s.runtime != nil {
+		if err := s.runtime.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("runtime shutdown: %w", err))
+		}
+	}
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http server shutdown: %w", err))
+		}
+	}
+	// the last status updates may have finished jobs whose callbacks are still notified
+	if s.notifier != nil {
+		if err := s.notifier.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("callback notifier shutdown: %w", err))
+		}
+	}
+	if s.storage != nil {
+		if err := s.storage.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("storage close: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// refuseJobs answers a request that would start an evaluation job with a 503 once the
+// server is shutting down, it reports whether the request was answered.
+func (s *Server) refuseJobs(ctx *executioncontext.ExecutionContext, resp RespWrapper) bool {
+	if !s.shuttingDown.Load() {
+		return false
+	}
+	resp.ErrorWithMessageCode(ctx.RequestID, messages.ServiceShuttingDown)
+	return true
 }
 
 type ServerClosedError struct {
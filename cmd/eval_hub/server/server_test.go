@@ -80,6 +80,8 @@ func TestServerSetupRoutes(t *testing.T) {
 		body   string
 	}{
 		{http.MethodGet, "/api/v1/health", http.StatusOK, ""},
+		{http.MethodGet, "/healthz", http.StatusOK, ""},
+		{http.MethodGet, "/readyz", http.StatusOK, ""},
 		{http.MethodGet, "/metrics", http.StatusOK, ""},
 		{http.MethodGet, "/openapi.yaml", http.StatusOK, ""},
 		{http.MethodGet, "/docs", http.StatusOK, ""},
@@ -87,20 +89,28 @@ func TestServerSetupRoutes(t *testing.T) {
 		{http.MethodPost, "/api/v1/evaluations/jobs", http.StatusAccepted, `{"model": {"url": "http://test.com", "name": "test"}, "benchmarks": [{"id": "bench-1", "provider_id": "garak"}]}`},
 		{http.MethodGet, "/api/v1/evaluations/jobs", http.StatusOK, ""},
 		{http.MethodGet, "/api/v1/evaluations/jobs/test-id", http.StatusNotFound, ""},
+		{http.MethodGet, "/api/v1/evaluations/jobs/test-id/benchmarks/bench-1/logs", http.StatusNotFound, ""},
+		{http.MethodGet, "/api/v1/evaluations/jobs/test-id/summary", http.StatusNotFound, ""},
+		{http.MethodDelete, "/api/v1/evaluations/jobs/test-id/benchmarks/bench-1", http.StatusNotFound, ""},
+		{http.MethodGet, "/api/v1/evaluations/jobs/test-id/benchmarks/bench-1", http.StatusMethodNotAllowed, ""},
 		// we can not delete because we have no id
 		// Benchmarks
 		{http.MethodGet, "/api/v1/evaluations/benchmarks", http.StatusOK, ""},
 		// Collections
-		{http.MethodGet, "/api/v1/evaluations/collections", http.StatusNotImplemented, ""},
-		{http.MethodPost, "/api/v1/evaluations/collections", http.StatusNotImplemented, ""},
-		{http.MethodGet, "/api/v1/evaluations/collections/test-collection", http.StatusNotImplemented, ""},
-		{http.MethodPut, "/api/v1/evaluations/collections/test-collection", http.StatusNotImplemented, ""},
-		{http.MethodPatch, "/api/v1/evaluations/collections/test-collection", http.StatusNotImplemented, ""},
-		{http.MethodDelete, "/api/v1/evaluations/collections/test-collection", http.StatusNotImplemented, ""},
+		{http.MethodGet, "/api/v1/evaluations/collections", http.StatusOK, ""},
+		{http.MethodPost, "/api/v1/evaluations/collections", http.StatusBadRequest, ""},
+		{http.MethodGet, "/api/v1/evaluations/collections/test-collection", http.StatusNotFound, ""},
+		{http.MethodPut, "/api/v1/evaluations/collections/test-collection", http.StatusBadRequest, ""},
+		{http.MethodPatch, "/api/v1/evaluations/collections/test-collection", http.StatusNotFound, ""},
+		{http.MethodDelete, "/api/v1/evaluations/collections/test-collection", http.StatusNotFound, ""},
+		{http.MethodPost, "/api/v1/evaluations/collections/test-collection/run", http.StatusBadRequest, ""},
 		// Providers
 		{http.MethodGet, "/api/v1/evaluations/providers", http.StatusOK, ""},
+		// System metrics
+		{http.MethodGet, "/api/v1/metrics/system", http.StatusOK, ""},
 		// Error cases
 		{http.MethodPost, "/api/v1/health", http.StatusMethodNotAllowed, ""},
+		{http.MethodPost, "/readyz", http.StatusMethodNotAllowed, ""},
 		{http.MethodGet, "/nonexistent", http.StatusNotFound, ""},
 	}
 
@@ -186,6 +196,44 @@ func TestServerShutdown(t *testing.T) {
 	})
 }
 
+func TestServerRefusesJobsWhileShuttingDown(t *testing.T) {
+	srv, err := createServer(0)
+	if err != nil {
+		t.Fatalf("NewServer() returned error: %v", err)
+	}
+	handler, err := srv.SetupRoutes()
+	if err != nil {
+		t.Fatalf("SetupRoutes() returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	body := `{"model": {"url": "http://test.com", "name": "test"}, "benchmarks": [{"id": "bench-1", "provider_id": "garak"}]}`
+	paths := []string{
+		"/api/v1/evaluations/jobs",
+		"/api/v1/evaluations/jobs/test-id/retry",
+		"/api/v1/evaluations/collections/test-id/run",
+	}
+	for _, path := range paths {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusServiceUnavailable, path, w.Code)
+		}
+	}
+	// the other endpoints keep answering while the service drains
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d for the health check, got %d", http.StatusOK, w.Code)
+	}
+}
+
 func createServer(port int) (*server.Server, error) {
 	logger, _, err := logging.NewLogger()
 	if err != nil {
@@ -216,7 +264,7 @@ func createServer(port int) (*server.Server, error) {
 		return nil, fmt.Errorf("failed to create runtime: %w", err)
 	}
 	mlflowClient := mlflow.NewMLFlowClient()
-	return server.NewServer(logger, serviceConfig, providerConfigs, storage, validate, runtime, mlflowClient)
+	return server.NewServer(logger, serviceConfig, providerConfigs, storage, validate, runtime, mlflowClient, nil)
 }
 
 func getKeyAsString(obj map[string]interface{}, key string) string {
@@ -2,6 +2,8 @@ package abstractions
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -15,7 +17,97 @@ type Runtime interface {
 	WithLogger(logger *slog.Logger) Runtime
 	WithContext(ctx context.Context) Runtime
 	Name() string
+	// RunEvaluationJob submits the benchmarks of the job. A benchmark that could not be
+	// submitted is recorded as failed in the storage, and returned as a BenchmarkError joined
+	// in the error when it failed before RunEvaluationJob returned. The benchmarks that were
+	// submitted keep running.
 	RunEvaluationJob(evaluation *api.EvaluationJobResource, storage *Storage) error
+	// CancelEvaluationJob stops the running benchmarks of the job and removes the resources
+	// created for them. Cancelling a job that has nothing running is not an error.
+	CancelEvaluationJob(jobID string) error
+	// CancelBenchmark stops the benchmark of the job and removes the resources created for
+	// it, the other benchmarks of the job keep running. Cancelling a benchmark that has
+	// nothing running is not an error.
+	CancelBenchmark(jobID string, benchmarkID string) error
+	// CountActiveJobs returns the number of benchmark workloads created by the runtime that
+	// have not finished yet.
+	CountActiveJobs() (int, error)
+	// StreamBenchmarkLogs returns the logs of the workload running the benchmark of the job,
+	// the stream ends when the context of the runtime is cancelled. ErrBenchmarkNotFound is
+	// returned when the runtime has no workload for the benchmark.
+	StreamBenchmarkLogs(jobID string, benchmarkID string, options LogOptions) (io.ReadCloser, error)
+	// RenderEvaluationJob returns the resources RunEvaluationJob would create for the job
+	// without creating them. The job goes through the same validation as when it is run.
+	RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error)
+	// Shutdown stops the watchers of the running workloads and waits for the submissions in
+	// progress to finish, until the context is done. No job can be run afterwards.
+	Shutdown(ctx context.Context) error
+}
+
+// LogOptions selects the benchmark logs returned by a runtime.
+type LogOptions struct {
+	// Follow keeps the stream open until the benchmark finishes.
+	Follow bool
+	// TailLines limits the logs to the last lines, all the logs are returned when nil.
+	TailLines *int64
+}
+
+// ErrBenchmarkNotFound is returned when a runtime has no workload for the benchmark of a job.
+var ErrBenchmarkNotFound = errors.New("benchmark workload not found")
+
+// BenchmarkError is the error of a benchmark whose workload could not be submitted, the
+// other benchmarks of the job are not affected by it. The runtimes already name the job and
+// the benchmark in the message of Err.
+type BenchmarkError struct {
+	ProviderID  string
+	BenchmarkID string
+	Err         error
+}
+
+func (e *BenchmarkError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BenchmarkError) Unwrap() error {
+	return e.Err
+}
+
+// BenchmarkErrors returns the BenchmarkErrors joined or wrapped in the error of
+// RunEvaluationJob. The boolean is false when the error also holds an error that is not
+// about a single benchmark, such as a validation error of the job.
+func BenchmarkErrors(err error) ([]*BenchmarkError, bool) {
+	switch err := err.(type) {
+	case nil:
+		return nil, true
+	case *BenchmarkError:
+		return []*BenchmarkError{err}, true
+	case interface{ Unwrap() []error }:
+		var benchmarkErrs []*BenchmarkError
+		for _, err := range err.Unwrap() {
+			errs, ok := BenchmarkErrors(err)
+			if !ok {
+				return nil, false
+			}
+			benchmarkErrs = append(benchmarkErrs, errs...)
+		}
+		return benchmarkErrs, true
+	case interface{ Unwrap() error }:
+		return BenchmarkErrors(err.Unwrap())
+	}
+	return nil, false
+}
+
+// ReadinessCheck tells whether a dependency of the service can be reached. The check must
+// return once its context is done.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ReadinessChecker is implemented by the runtimes that depend on other services, such as the
+// Kubernetes API server. The readiness probe of the service runs their checks.
+type ReadinessChecker interface {
+	ReadinessChecks() []ReadinessCheck
 }
 
 // This intrerface must be decoupled from the service HTTP layer
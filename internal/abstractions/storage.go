@@ -13,6 +13,25 @@ type QueryResults[T any] struct {
 	TotalStored int
 }
 
+// EvaluationJobFilter selects the evaluation jobs that are listed, the conditions that are
+// set must all hold. The creation time bounds are exclusive.
+type EvaluationJobFilter struct {
+	Status        string
+	ModelName     string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// After keeps the jobs that are listed after the given one, the page that follows it in
+	// the list. It is not applied to the total count of the list.
+	After *EvaluationJobCursor
+}
+
+// EvaluationJobCursor is the position of a job in the list of the evaluation jobs, which
+// lists the newest jobs first and the jobs created at the same time by descending ID.
+type EvaluationJobCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
 type Storage interface {
 	WithLogger(logger *slog.Logger) Storage
 	WithContext(ctx context.Context) Storage
@@ -23,13 +42,22 @@ type Storage interface {
 	Ping(timeout time.Duration) error
 
 	// Evaluation job operations
-	CreateEvaluationJob(evaluation *api.EvaluationJobConfig, mlflowExperimentID string) (*api.EvaluationJobResource, error)
+	// CreateEvaluationJob stores a new evaluation job, the idempotency key is empty when the client did not send one
+	CreateEvaluationJob(evaluation *api.EvaluationJobConfig, mlflowExperimentID string, idempotencyKey string) (*api.EvaluationJobResource, error)
 	GetEvaluationJob(id string) (*api.EvaluationJobResource, error)
-	GetEvaluationJobs(limit int, offset int, statusFilter string) (*QueryResults[api.EvaluationJobResource], error)
+	// FindEvaluationJobByIdempotencyKey returns nil when no evaluation job was created with the key
+	FindEvaluationJobByIdempotencyKey(idempotencyKey string) (*api.EvaluationJobResource, error)
+	GetEvaluationJobs(limit int, offset int, filter EvaluationJobFilter) (*QueryResults[api.EvaluationJobResource], error)
 	DeleteEvaluationJob(id string, hardDelete bool) error
 	UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error
 	// UpdateEvaluationJobStatus is used to update the status of an evaluation job and is internal - do we need it here?
 	UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) error
+	// AddEvaluationJobArtifacts attaches artifact references to the evaluation job, a reference replaces the one of the same benchmark
+	AddEvaluationJobArtifacts(id string, artifacts []api.ArtifactReference) error
+	// ResetEvaluationJobBenchmarks sets the benchmarks back to pending and clears their results so that they can run again
+	ResetEvaluationJobBenchmarks(id string, benchmarkIDs []string) error
+	// CountEvaluationJobsByStatus returns the number of evaluation jobs in each status, statuses without jobs are left out
+	CountEvaluationJobsByStatus() (map[api.OverallState]int, error)
 
 	// Collection operations
 	CreateCollection(collection *api.CollectionResource) error
@@ -42,5 +70,20 @@ type Storage interface {
 	Close() error
 }
 
+// TransitionObserver is called with an evaluation job whose update changed its overall
+// state, once the update is committed. previous is the state before the update.
+type TransitionObserver func(previous api.OverallState, job *api.EvaluationJobResource)
+
+// ObservableStorage is implemented by the storages that report the changes of the overall
+// state of the evaluation jobs. The state before and after an update are read and written in
+// the transaction of the update, so a change is reported once even when updates of the same
+// job run concurrently.
+type ObservableStorage interface {
+	Storage
+	// WithTransitionObserver returns the storage reporting the changes to the observer, the
+	// storages derived from it with WithLogger and WithContext report them too.
+	WithTransitionObserver(observer TransitionObserver) Storage
+}
+
 // This interface must be decoupled from the service HTTP layer.
 // Do not pass ExecutionContext, Request or Response wrappers either.
@@ -0,0 +1,212 @@
+package callbacks
+
+// Notification of the callback URL of a finished evaluation job.
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the payload, see Sign
+	SignatureHeader = "X-EvalHub-Signature"
+	// TimestampHeader carries the Unix time the payload was signed at
+	TimestampHeader = "X-EvalHub-Timestamp"
+
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	defaultTimeout     = 10 * time.Second
+)
+
+// Clock tells the time and waits, tests replace it so that the retries do not wait.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Notifier POSTs a finished evaluation job to its callback URL.
+type Notifier struct {
+	logger      *slog.Logger
+	client      *http.Client
+	clock       Clock
+	secret      []byte
+	maxAttempts int
+	backoff     time.Duration
+
+	// the notifications that run in the background, see NotifyInBackground
+	mu      sync.Mutex
+	closing bool
+	running sync.WaitGroup
+	// notifying is done when the notifications in the background must stop
+	notifying     context.Context
+	stopNotifying context.CancelFunc
+}
+
+// NewNotifier creates a notifier, the callback config is optional. A nil client or clock is
+// replaced by an HTTP client with the configured timeout and the system clock.
+func NewNotifier(logger *slog.Logger, callbackConfig *config.CallbackConfig, client *http.Client, clock Clock) *Notifier {
+	settings := config.CallbackConfig{}
+	if callbackConfig != nil {
+		settings = *callbackConfig
+	}
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = defaultMaxAttempts
+	}
+	if settings.Backoff <= 0 {
+		settings.Backoff = defaultBackoff
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultTimeout
+	}
+	if client == nil {
+		client = &http.Client{Timeout: settings.Timeout}
+	}
+	if clock == nil {
+		clock = systemClock{}
+	}
+	if settings.Secret == "" {
+		logger.Warn("No callback secret is configured, the callback payloads are not signed")
+	}
+	notifying, stopNotifying := context.WithCancel(context.Background())
+	return &Notifier{
+		logger:        logger,
+		client:        client,
+		clock:         clock,
+		secret:        []byte(settings.Secret),
+		maxAttempts:   settings.MaxAttempts,
+		backoff:       settings.Backoff,
+		notifying:     notifying,
+		stopNotifying: stopNotifying,
+	}
+}
+
+// NotifyInBackground notifies the callback URL of the job without waiting for it, the
+// failure of the notification is only logged. Once the notifier is shutting down the
+// notification is dropped, which is logged as well.
+func (n *Notifier) NotifyInBackground(job *api.EvaluationJobResource) {
+	n.mu.Lock()
+	if n.closing {
+		n.mu.Unlock()
+		n.logger.Error("The evaluation job callback is not notified, the service is shutting down", "job_id", job.Resource.ID, "state", job.Status.State)
+		return
+	}
+	n.running.Add(1)
+	n.mu.Unlock()
+	go func() {
+		defer n.running.Done()
+		if err := n.Notify(n.notifying, job); err != nil {
+			n.logger.Error("Failed to notify the evaluation job callback", "job_id", job.Resource.ID, "error", err.Error())
+			return
+		}
+		n.logger.Info("Notified the evaluation job callback", "job_id", job.Resource.ID, "state", job.Status.State)
+	}()
+}
+
+// Shutdown drops the new notifications and waits for the ones in the background to finish,
+// retries included. Those still running when the context is done are stopped, and their
+// failure is logged before Shutdown returns.
+func (n *Notifier) Shutdown(ctx context.Context) error {
+	n.mu.Lock()
+	n.closing = true
+	n.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		n.running.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		n.stopNotifying()
+		return nil
+	case <-ctx.Done():
+		n.stopNotifying()
+		<-finished
+		return fmt.Errorf("callback notifications still running: %w", ctx.Err())
+	}
+}
+
+// Notify POSTs the summary of the job to its callback URL, as the summary endpoint returns
+// it. A 5xx response or a failed request is retried with an exponential backoff until the
+// attempts are used up, any other response that is not a 2xx fails the notification at once.
+func (n *Notifier) Notify(ctx context.Context, job *api.EvaluationJobResource) error {
+	body, err := json.Marshal(api.NewSummaryResponse(job))
+	if err != nil {
+		return fmt.Errorf("callback of job %s: %w", job.Resource.ID, err)
+	}
+	backoff := n.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := n.send(ctx, job.CallbackURL, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= n.maxAttempts {
+			return fmt.Errorf("callback of job %s failed after %d attempts: %w", job.Resource.ID, attempt, err)
+		}
+		n.logger.Warn("Callback attempt failed", "job_id", job.Resource.ID, "attempt", attempt, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("callback of job %s: %w", job.Resource.ID, ctx.Err())
+		case <-n.clock.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send makes one attempt, it reports whether a failed attempt may be retried.
+func (n *Notifier) send(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(n.clock.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	if len(n.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(n.secret, timestamp, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 500:
+		return true, fmt.Errorf("callback returned status %d", resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return false, fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// Sign returns the signature of the payload, "sha256=" followed by the hex encoded
+// HMAC-SHA256 of the timestamp, a dot and the body. A receiver verifies the payload by
+// computing it with the shared secret.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,333 @@
+package callbacks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/callbacks"
+	"github.com/eval-hub/eval-hub/internal/config"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/storage"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now.Add(d)
+	return ch
+}
+
+// callbackServer answers with the statuses in turn, the last one is repeated.
+type callbackServer struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+	received chan struct{}
+}
+
+func (s *callbackServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	status := s.statuses[min(len(s.requests), len(s.statuses)-1)]
+	s.requests = append(s.requests, r)
+	s.bodies = append(s.bodies, body)
+	s.mu.Unlock()
+	w.WriteHeader(status)
+	if s.received != nil {
+		s.received <- struct{}{}
+	}
+}
+
+func newNotifier(clock callbacks.Clock) *callbacks.Notifier {
+	callbackConfig := &config.CallbackConfig{Secret: "top-secret", MaxAttempts: 3, Backoff: time.Second}
+	return callbacks.NewNotifier(logging.FallbackLogger(), callbackConfig, nil, clock)
+}
+
+func TestNotifySignsAndRetriesServerErrors(t *testing.T) {
+	receiver := &callbackServer{statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+
+	job := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}},
+		Results: &api.EvaluationJobResults{
+			Benchmarks: []api.BenchmarkResult{{ID: "arc_easy", ProviderID: "lm_evaluation_harness", Metrics: map[string]any{"acc": 0.8}}},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks:  []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+			CallbackURL: server.URL,
+		},
+	}
+	if err := newNotifier(clock).Notify(context.Background(), job); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if len(receiver.requests) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(receiver.requests))
+	}
+	if len(clock.waits) != 2 || clock.waits[0] != time.Second || clock.waits[1] != 2*time.Second {
+		t.Fatalf("expected an exponential backoff, got %v", clock.waits)
+	}
+
+	request, body := receiver.requests[2], receiver.bodies[2]
+	timestamp := request.Header.Get(callbacks.TimestampHeader)
+	if timestamp != "1700000000" {
+		t.Fatalf("unexpected timestamp %q", timestamp)
+	}
+	if request.Header.Get(callbacks.SignatureHeader) != callbacks.Sign([]byte("top-secret"), timestamp, body) {
+		t.Fatalf("unexpected signature %q", request.Header.Get(callbacks.SignatureHeader))
+	}
+	payload := &api.SummaryResponse{}
+	if err := json.Unmarshal(body, payload); err != nil || payload.ID != "job-1" || payload.State != api.OverallStateCompleted {
+		t.Fatalf("expected the summary of the job as payload, got %s", body)
+	}
+	if len(payload.Benchmarks) != 1 || payload.Benchmarks[0].ID != "arc_easy" || payload.Benchmarks[0].Metrics["acc"] != 0.8 {
+		t.Fatalf("expected the outcome of the benchmarks, got %+v", payload.Benchmarks)
+	}
+}
+
+func TestNotifyDoesNotRetryClientErrors(t *testing.T) {
+	receiver := &callbackServer{statuses: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	job := &api.EvaluationJobResource{EvaluationJobConfig: api.EvaluationJobConfig{CallbackURL: server.URL}}
+	err := newNotifier(&fakeClock{}).Notify(context.Background(), job)
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Fatalf("expected a client error, got %v", err)
+	}
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected a single attempt, got %d", len(receiver.requests))
+	}
+}
+
+func TestNotifyGivesUpAfterMaxAttempts(t *testing.T) {
+	receiver := &callbackServer{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	job := &api.EvaluationJobResource{EvaluationJobConfig: api.EvaluationJobConfig{CallbackURL: server.URL}}
+	err := newNotifier(&fakeClock{}).Notify(context.Background(), job)
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Fatalf("expected the notification to give up, got %v", err)
+	}
+	if len(receiver.requests) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(receiver.requests))
+	}
+}
+
+func TestStorageNotifiesFinishedJobOnce(t *testing.T) {
+	receiver := &callbackServer{statuses: []int{http.StatusInternalServerError}, received: make(chan struct{}, 10)}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:callbacks?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	sqlStore, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	callbackConfig := &config.CallbackConfig{MaxAttempts: 1}
+	notifier := callbacks.NewNotifier(logging.FallbackLogger(), callbackConfig, nil, &fakeClock{})
+	store := callbacks.NewStorage(sqlStore, notifier, logging.FallbackLogger()).WithContext(context.Background())
+
+	job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+		Model:       api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks:  []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		CallbackURL: server.URL,
+	}, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	updates := []api.State{api.StateRunning, api.StateCompleted, api.StateCompleted}
+	for _, state := range updates {
+		err := store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: state},
+		})
+		if err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+	}
+
+	select {
+	case <-receiver.received:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the callback to be notified")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(receiver.received) != 0 {
+		t.Fatalf("expected a single notification")
+	}
+
+	// the failed callback leaves the job as it is
+	stored, err := store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if stored.Status.State != api.OverallStateCompleted {
+		t.Fatalf("expected the job to stay completed, got %s", stored.Status.State)
+	}
+}
+
+func TestStorageNotifiesConcurrentlyFinishedJobOnce(t *testing.T) {
+	receiver := &callbackServer{statuses: []int{http.StatusOK}, received: make(chan struct{}, 10)}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	databaseConfig := map[string]any{
+		"driver":         "sqlite",
+		"url":            "file:callbacks_concurrent?mode=memory&cache=shared",
+		"database_name":  "eval_hub",
+		"max_open_conns": 1,
+	}
+	sqlStore, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	callbackConfig := &config.CallbackConfig{MaxAttempts: 1}
+	notifier := callbacks.NewNotifier(logging.FallbackLogger(), callbackConfig, nil, &fakeClock{})
+	store := callbacks.NewStorage(sqlStore, notifier, logging.FallbackLogger()).WithContext(context.Background())
+
+	benchmarkIDs := []string{"arc_easy", "hellaswag", "mmlu", "winogrande"}
+	benchmarks := []api.BenchmarkConfig{}
+	for _, id := range benchmarkIDs {
+		benchmarks = append(benchmarks, api.BenchmarkConfig{Ref: api.Ref{ID: id}, ProviderID: "lm_evaluation_harness"})
+	}
+	job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+		Model:       api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks:  benchmarks,
+		CallbackURL: server.URL,
+	}, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// the benchmarks finish together, each update is applied to the job left by the previous
+	// one and only the update that finishes the job notifies
+	var wg sync.WaitGroup
+	errs := make(chan error, len(benchmarkIDs))
+	for _, id := range benchmarkIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+				BenchmarkStatusEvent: &api.BenchmarkStatusEvent{ProviderID: "lm_evaluation_harness", ID: id, Status: api.StateCompleted},
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+	}
+
+	select {
+	case <-receiver.received:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the callback to be notified")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(receiver.received) != 0 {
+		t.Fatalf("expected a single notification")
+	}
+}
+
+// blockingServer holds every request until it is released or the request is cancelled.
+type blockingServer struct {
+	arrived chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, _ = io.ReadAll(r.Body)
+	s.arrived <- struct{}{}
+	select {
+	case <-s.release:
+		w.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+	}
+}
+
+func finishedJob(callbackURL string) *api.EvaluationJobResource {
+	return &api.EvaluationJobResource{
+		Resource:            api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+		Status:              &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}},
+		EvaluationJobConfig: api.EvaluationJobConfig{CallbackURL: callbackURL},
+	}
+}
+
+func TestNotifierShutdownWaitsForNotifications(t *testing.T) {
+	receiver := &blockingServer{arrived: make(chan struct{}, 1), release: make(chan struct{})}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	notifier := newNotifier(&fakeClock{})
+
+	notifier.NotifyInBackground(finishedJob(server.URL))
+	<-receiver.arrived
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- notifier.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-shutdown:
+		t.Fatalf("expected Shutdown to wait for the notification, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(receiver.release)
+	if err := <-shutdown; err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+
+	// the notifications after the shutdown are dropped
+	notifier.NotifyInBackground(finishedJob(server.URL))
+	select {
+	case <-receiver.arrived:
+		t.Fatalf("expected no notification after the shutdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNotifierShutdownStopsNotificationsAtDeadline(t *testing.T) {
+	receiver := &blockingServer{arrived: make(chan struct{}, 1), release: make(chan struct{})}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	defer close(receiver.release)
+	notifier := newNotifier(&fakeClock{})
+
+	notifier.NotifyInBackground(finishedJob(server.URL))
+	<-receiver.arrived
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := notifier.Shutdown(ctx)
+	if err == nil || !strings.Contains(err.Error(), "callback notifications still running") {
+		t.Fatalf("expected the notification to be stopped at the deadline, got %v", err)
+	}
+}
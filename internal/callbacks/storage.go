@@ -0,0 +1,36 @@
+package callbacks
+
+import (
+	"log/slog"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// NewStorage returns the storage that notifies the callback URL of an evaluation job when one
+// of its updates finishes the job. Every status update goes through the storage, whether it
+// comes from the adapter, the runtime or a cancel, so none of them is missed. The storage
+// reports the change of state from the transaction of the update, so two concurrent updates
+// cannot both finish the job and the callback is notified once.
+func NewStorage(storage abstractions.Storage, notifier *Notifier, logger *slog.Logger) abstractions.Storage {
+	observable, ok := storage.(abstractions.ObservableStorage)
+	if !ok {
+		logger.Warn("The storage does not report the state changes of the evaluation jobs, the callback URLs are not notified")
+		return storage
+	}
+	return observable.WithTransitionObserver(func(previous api.OverallState, job *api.EvaluationJobResource) {
+		if job.CallbackURL == "" || isFinished(previous) || !isFinished(job.Status.State) {
+			return
+		}
+		// the notification never changes the stored job
+		notifier.NotifyInBackground(job)
+	})
+}
+
+func isFinished(state api.OverallState) bool {
+	switch state {
+	case api.OverallStateCompleted, api.OverallStateFailed, api.OverallStatePartiallyFailed, api.OverallStateCancelled:
+		return true
+	}
+	return false
+}
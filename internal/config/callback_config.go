@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+// CallbackConfig configures how the callback URL of an evaluation job is notified, every
+// setting has a default when it is not set.
+type CallbackConfig struct {
+	// Secret is the HMAC key that signs the payload, the payload is not signed without one
+	Secret string `mapstructure:"secret"`
+	// MaxAttempts bounds the attempts of a notification that fails with a 5xx or a timeout
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// Backoff is the wait before the second attempt, it doubles with every further attempt
+	Backoff time.Duration `mapstructure:"backoff"`
+	// Timeout bounds a single attempt
+	Timeout time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// CleanupConfig configures the reconciler that deletes the benchmark ConfigMaps whose Job is
+// gone. The ConfigMaps are normally deleted with their Job, this catches the ones whose owner
+// reference was never set.
+type CleanupConfig struct {
+	// Enabled starts the reconciler, nothing is deleted when it is false
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the time between two runs of the reconciler, 10 minutes when unset
+	Interval time.Duration `mapstructure:"interval"`
+}
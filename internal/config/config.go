@@ -1,6 +1,14 @@
 package config
 
+import "github.com/eval-hub/eval-hub/pkg/api"
+
 type Config struct {
 	Service  *ServiceConfig  `mapstructure:"service"`
 	Database *map[string]any `mapstructure:"database"`
+	// Artifacts is where the benchmark Jobs upload their outputs, nothing is uploaded when unset
+	Artifacts *api.ArtifactSink `mapstructure:"artifacts"`
+	// Callbacks configures the notification of the callback URL of a finished evaluation job
+	Callbacks *CallbackConfig `mapstructure:"callbacks"`
+	// Cleanup configures the deletion of the benchmark ConfigMaps whose Job is gone
+	Cleanup *CleanupConfig `mapstructure:"cleanup"`
 }
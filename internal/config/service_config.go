@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type ServiceConfig struct {
 	Version         string `mapstructure:"version,omitempty"`
 	Build           string `mapstructure:"build,omitempty"`
@@ -8,4 +10,10 @@ type ServiceConfig struct {
 	ReadyFile       string `mapstructure:"ready_file"`
 	TerminationFile string `mapstructure:"termination_file"`
 	LocalMode       bool   `mapstructure:"local_mode,omitempty"`
+	// RequestTimeout bounds the storage and runtime calls made for a request, 60s when unset
+	RequestTimeout time.Duration `mapstructure:"request_timeout,omitempty"`
+	// MaxRequestBodyBytes caps the size of a request body, 1MiB when unset. RequestBodyLimits
+	// overrides it for the routes it lists by their pattern, e.g. /api/v1/evaluations/jobs.
+	MaxRequestBodyBytes int64            `mapstructure:"max_request_body_bytes,omitempty"`
+	RequestBodyLimits   map[string]int64 `mapstructure:"request_body_limits,omitempty"`
 }
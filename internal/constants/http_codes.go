@@ -10,6 +10,10 @@ const (
 	HTTPCodeForbidden           = 403
 	HTTPCodeNotFound            = 404
 	HTTPCodeMethodNotAllowed    = 405
+	HTTPCodeConflict            = 409
+	HTTPCodeRequestTooLarge     = 413
+	HTTPCodeUnprocessableEntity = 422
 	HTTPCodeInternalServerError = 500
 	HTTPCodeNotImplemented      = 501
+	HTTPCodeServiceUnavailable  = 503
 )
@@ -6,4 +6,6 @@ const (
 	MESSAGE_CODE_EVALUATION_JOB_CANCELLED = "evaluation_job_cancelled"
 	MESSAGE_CODE_EVALUATION_JOB_FAILED    = "evaluation_job_failed"
 	MESSAGE_CODE_EVALUATION_JOB_UPDATED   = "evaluation_job_updated"
+	MESSAGE_CODE_BENCHMARK_NO_METRICS     = "benchmark_no_metrics"
+	MESSAGE_CODE_BENCHMARK_CANCELLED      = "benchmark_cancelled"
 )
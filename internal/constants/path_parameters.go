@@ -3,4 +3,5 @@ package constants
 const (
 	PATH_PARAMETER_JOB_ID        = "job_id"
 	PATH_PARAMETER_COLLECTION_ID = "collection_id"
+	PATH_PARAMETER_BENCHMARK_ID  = "benchmark_id"
 )
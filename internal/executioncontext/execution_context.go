@@ -20,13 +20,21 @@ type ExecutionContext struct {
 	StartedAt time.Time
 }
 
-// This struct contains per request context information
+// This struct contains per request context information. A positive timeout bounds Ctx, which
+// is also done when the parent context is, e.g. when the client disconnects.
 func NewExecutionContext(
 	ctx context.Context,
 	requestID string,
 	logger *slog.Logger,
 	timeout time.Duration,
 ) *ExecutionContext {
+	if timeout > 0 {
+		parent := ctx
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		// release the timer as soon as the request is over rather than at the deadline
+		context.AfterFunc(parent, cancel)
+	}
 	return &ExecutionContext{
 		Ctx:       ctx,
 		RequestID: requestID,
@@ -0,0 +1,36 @@
+package executioncontext_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+func TestNewExecutionContextTimeout(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), 10*time.Millisecond)
+	select {
+	case <-ctx.Ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the context to time out")
+	}
+	if !errors.Is(ctx.Ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", ctx.Ctx.Err())
+	}
+}
+
+func TestNewExecutionContextFollowsParent(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := executioncontext.NewExecutionContext(parent, "req-2", logging.FallbackLogger(), time.Minute)
+	if ctx.Ctx.Err() != nil {
+		t.Fatalf("expected a live context, got %v", ctx.Ctx.Err())
+	}
+	// a client disconnect cancels the request context
+	cancel()
+	if !errors.Is(ctx.Ctx.Err(), context.Canceled) {
+		t.Fatalf("expected the context to be canceled with its parent, got %v", ctx.Ctx.Err())
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleListEvaluationArtifacts handles GET /api/v1/evaluations/jobs/{id}/artifacts
+//
+// The artifacts of a benchmark are listed once it was submitted, the files are in the
+// artifact sink when the benchmark has finished.
+func (h *Handlers) HandleListEvaluationArtifacts(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	job, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	items := job.Artifacts
+	if items == nil {
+		items = []api.ArtifactReference{}
+	}
+	w.WriteJSON(api.ArtifactReferenceList{Items: items}, 200)
+}
@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/go-playground/validator/v10"
+)
+
+func listArtifacts(t *testing.T, job *api.EvaluationJobResource) api.ArtifactReferenceList {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.New(&fakeStorage{job: job}, validator.New(), &fakeRuntime{}, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-artifacts", logger, time.Second)
+
+	req := createMockRequest("GET", "/api/v1/evaluations/jobs/job-1/artifacts")
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	recorder := httptest.NewRecorder()
+	h.HandleListEvaluationArtifacts(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	list := api.ArtifactReferenceList{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+	return list
+}
+
+func TestHandleListEvaluationArtifacts(t *testing.T) {
+	list := listArtifacts(t, &api.EvaluationJobResource{
+		Artifacts: []api.ArtifactReference{{BenchmarkID: "bench-1", ProviderID: "garak", URI: "s3://bucket/job-1/bench-1/"}},
+	})
+	if len(list.Items) != 1 || list.Items[0].URI != "s3://bucket/job-1/bench-1/" {
+		t.Fatalf("unexpected artifacts %+v", list.Items)
+	}
+
+	list = listArtifacts(t, &api.EvaluationJobResource{})
+	if list.Items == nil || len(list.Items) != 0 {
+		t.Fatalf("expected an empty list, got %+v", list.Items)
+	}
+}
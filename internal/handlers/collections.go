@@ -1,37 +1,280 @@
 package handlers
 
 import (
+	"encoding/json"
+
+	"github.com/eval-hub/eval-hub/internal/constants"
 	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/logging"
 	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serialization"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/validation"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
 )
 
 // HandleListCollections handles GET /api/v1/evaluations/collections
-func (h *Handlers) HandleListCollections(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) {
-	w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", "list collections")
+func (h *Handlers) HandleListCollections(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	limit, err := getParam(r, "limit", true, 50)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	offset, err := getParam(r, "offset", true, 0)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	res, err := storage.GetCollections(limit, offset)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	page, err := CreatePage(res.TotalStored, offset, limit, ctx, r)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	w.WriteJSON(api.CollectionResourceList{
+		Page:  *page,
+		Items: res.Items,
+	}, 200)
 }
 
 // HandleCreateCollection handles POST /api/v1/evaluations/collections
-func (h *Handlers) HandleCreateCollection(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) {
-	w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", "create collection")
+func (h *Handlers) HandleCreateCollection(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	bodyBytes, err := r.BodyAsBytes()
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	request := &api.CollectionCreationRequest{}
+	err = serialization.Unmarshal(h.validate, ctx, bodyBytes, request)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	collection := &api.CollectionResource{
+		Resource:         api.Resource{ID: request.ID},
+		CollectionConfig: request.CollectionConfig,
+	}
+	err = storage.CreateCollection(collection)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(collection, 201)
 }
 
 // HandleGetCollection handles GET /api/v1/evaluations/collections/{collection_id}
-func (h *Handlers) HandleGetCollection(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) {
-	w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", "get collection")
+func (h *Handlers) HandleGetCollection(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	collectionID := r.PathValue(constants.PATH_PARAMETER_COLLECTION_ID)
+	if collectionID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_COLLECTION_ID), ctx.RequestID)
+		return
+	}
+
+	response, err := storage.GetCollection(collectionID, false)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(response, 200)
 }
 
 // HandleUpdateCollection handles PUT /api/v1/evaluations/collections/{collection_id}
-func (h *Handlers) HandleUpdateCollection(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) {
-	w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", "update collection")
+func (h *Handlers) HandleUpdateCollection(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	collectionID := r.PathValue(constants.PATH_PARAMETER_COLLECTION_ID)
+	if collectionID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_COLLECTION_ID), ctx.RequestID)
+		return
+	}
+
+	bodyBytes, err := r.BodyAsBytes()
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	config := &api.CollectionConfig{}
+	err = serialization.Unmarshal(h.validate, ctx, bodyBytes, config)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	collection, err := storage.GetCollection(collectionID, false)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	collection.CollectionConfig = *config
+	err = storage.UpdateCollection(collection)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(collection, 200)
 }
 
 // HandlePatchCollection handles PATCH /api/v1/evaluations/collections/{collection_id}
-func (h *Handlers) HandlePatchCollection(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) {
-	w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", "patch collection")
+// The request body is an RFC 7386 JSON merge patch that is applied to the stored collection,
+// the merged collection is validated again before it is persisted.
+func (h *Handlers) HandlePatchCollection(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	collectionID := r.PathValue(constants.PATH_PARAMETER_COLLECTION_ID)
+	if collectionID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_COLLECTION_ID), ctx.RequestID)
+		return
+	}
+
+	collection, err := storage.GetCollection(collectionID, false)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	bodyBytes, err := r.BodyAsBytes()
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	storedBytes, err := json.Marshal(&collection.CollectionConfig)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	mergedBytes, err := jsonpatch.MergePatch(storedBytes, bodyBytes)
+	if err != nil {
+		w.Error(serviceerrors.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error()), ctx.RequestID)
+		return
+	}
+	config := &api.CollectionConfig{}
+	err = serialization.Unmarshal(h.validate, ctx, mergedBytes, config)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	collection.CollectionConfig = *config
+	err = storage.UpdateCollection(collection)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(collection, 200)
+}
+
+// HandleRunCollection handles POST /api/v1/evaluations/collections/{collection_id}/run
+//
+// The benchmarks of the collection, with their parameters, are run against the model in
+// the request as a new evaluation job.
+func (h *Handlers) HandleRunCollection(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	collectionID := r.PathValue(constants.PATH_PARAMETER_COLLECTION_ID)
+	if collectionID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_COLLECTION_ID), ctx.RequestID)
+		return
+	}
+
+	bodyBytes, err := r.BodyAsBytes()
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	request := &api.CollectionRunRequest{}
+	err = serialization.Unmarshal(h.validate, ctx, bodyBytes, request)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	collection, err := storage.GetCollection(collectionID, false)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	evaluation := &api.EvaluationJobConfig{
+		Model:          request.Model,
+		Benchmarks:     expandCollectionBenchmarks(collection),
+		Collection:     api.Ref{ID: collection.Resource.ID},
+		Experiment:     request.Experiment,
+		TimeoutMinutes: request.TimeoutMinutes,
+		RetryAttempts:  request.RetryAttempts,
+	}
+	err = validation.ValidateEvaluationJobConfig(evaluation, h.providerConfigs)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	response, err := h.submitEvaluationJob(ctx, storage, evaluation, "")
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(response, 202)
+}
+
+// expandCollectionBenchmarks copies the benchmarks of the collection, with their parameters,
+// for an evaluation job.
+func expandCollectionBenchmarks(collection *api.CollectionResource) []api.BenchmarkConfig {
+	benchmarks := make([]api.BenchmarkConfig, 0, len(collection.Benchmarks))
+	for _, benchmark := range collection.Benchmarks {
+		expanded := api.BenchmarkConfig{
+			Ref:        benchmark.Ref,
+			ProviderID: benchmark.ProviderID,
+		}
+		if len(benchmark.Parameters) > 0 {
+			expanded.Parameters = make(map[string]any, len(benchmark.Parameters))
+			for key, value := range benchmark.Parameters {
+				expanded.Parameters[key] = value
+			}
+		}
+		benchmarks = append(benchmarks, expanded)
+	}
+	return benchmarks
 }
 
 // HandleDeleteCollection handles DELETE /api/v1/evaluations/collections/{collection_id}
-func (h *Handlers) HandleDeleteCollection(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) {
-	w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", "delete collection")
+func (h *Handlers) HandleDeleteCollection(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	collectionID := r.PathValue(constants.PATH_PARAMETER_COLLECTION_ID)
+	if collectionID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_COLLECTION_ID), ctx.RequestID)
+		return
+	}
+
+	err := storage.DeleteCollection(collectionID)
+	if err != nil {
+		ctx.Logger.Info("Failed to delete collection", "error", err.Error(), "id", collectionID)
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	w.WriteJSON(nil, 204)
 }
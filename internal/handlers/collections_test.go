@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/go-playground/validator/v10"
+)
+
+func runCollectionRequest(t *testing.T, providerID string) (*fakeStorage, *fakeRuntime, *httptest.ResponseRecorder) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{
+		collection: &api.CollectionResource{
+			Resource: api.Resource{ID: "collection-1"},
+			CollectionConfig: api.CollectionConfig{
+				Name: "safety",
+				Benchmarks: []api.BenchmarkConfig{
+					{Ref: api.Ref{ID: "toxicity"}, ProviderID: providerID, Parameters: map[string]any{"num_examples": 10}},
+				},
+			},
+		},
+	}
+	runtime := &fakeRuntime{}
+	providers := map[string]api.ProviderResource{"garak": {ProviderID: "garak"}}
+	h := handlers.New(storage, validator.New(), runtime, nil, providers, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-run", logger, time.Second)
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/collections/collection-1/run"),
+		body:        []byte(`{"model":{"url":"http://test.com","name":"test"}}`),
+	}
+	req.SetPathValue(constants.PATH_PARAMETER_COLLECTION_ID, "collection-1")
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+
+	h.HandleRunCollection(ctx, req, resp)
+	return storage, runtime, recorder
+}
+
+func TestHandleRunCollectionCreatesEvaluationJob(t *testing.T) {
+	storage, runtime, recorder := runCollectionRequest(t, "garak")
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !runtime.called {
+		t.Fatalf("expected runtime to be invoked")
+	}
+	created := storage.created
+	if created == nil || created.Model.Name != "test" || created.Collection.ID != "collection-1" {
+		t.Fatalf("unexpected evaluation job config: %+v", created)
+	}
+	if len(created.Benchmarks) != 1 || created.Benchmarks[0].ID != "toxicity" || created.Benchmarks[0].Parameters["num_examples"] != 10 {
+		t.Fatalf("expected the collection benchmarks to be expanded, got %+v", created.Benchmarks)
+	}
+}
+
+func TestHandleRunCollectionRejectsUnknownProvider(t *testing.T) {
+	storage, runtime, recorder := runCollectionRequest(t, "unknown")
+
+	if recorder.Code != constants.HTTPCodeUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), "benchmarks[0].provider_id") {
+		t.Fatalf("expected the benchmark provider to be named in the error, got %s", recorder.Body.String())
+	}
+	if storage.created != nil || runtime.called {
+		t.Fatalf("expected no evaluation job to be created")
+	}
+}
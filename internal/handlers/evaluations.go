@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"runtime/debug"
 	"strconv"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
 	"github.com/eval-hub/eval-hub/internal/constants"
@@ -14,6 +17,7 @@ import (
 	"github.com/eval-hub/eval-hub/internal/mlflow"
 	"github.com/eval-hub/eval-hub/internal/serialization"
 	"github.com/eval-hub/eval-hub/internal/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/validation"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
@@ -59,7 +63,49 @@ func getParam[T string | int | bool](r http_wrappers.RequestWrapper, name string
 	}
 }
 
+// getEvaluationJobFilter reads the filter of the evaluation jobs list from the query parameters.
+func getEvaluationJobFilter(r http_wrappers.RequestWrapper) (abstractions.EvaluationJobFilter, error) {
+	filter := abstractions.EvaluationJobFilter{}
+	var err error
+	if filter.Status, err = getParam(r, "status_filter", true, ""); err != nil {
+		return filter, err
+	}
+	if filter.ModelName, err = getParam(r, "model_name", true, ""); err != nil {
+		return filter, err
+	}
+	if filter.CreatedAfter, err = getTimestampParam(r, "created_after"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = getTimestampParam(r, "created_before"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// getTimestampParam returns the RFC3339 timestamp of an optional query parameter, nil when it is not set.
+func getTimestampParam(r http_wrappers.RequestWrapper, name string) (*time.Time, error) {
+	value, err := getParam(r, name, true, "")
+	if err != nil || value == "" {
+		return nil, err
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", name, "Type", "RFC3339 timestamp", "Value", value)
+	}
+	return &timestamp, nil
+}
+
+// dryRunJobID is the ID of the transient job that is rendered for a dry run.
+const dryRunJobID = "dry-run"
+
 // HandleCreateEvaluation handles POST /api/v1/evaluations/jobs
+//
+// With dry_run=true the job is validated and the resources that the runtime would create
+// for it are returned, nothing is stored or submitted.
+//
+// A request with an Idempotency-Key header that repeats an earlier one returns the job of
+// the earlier request, also when both run concurrently. When that job failed the request is
+// a conflict that points to the retry endpoint of the job.
 func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
 
@@ -77,22 +123,142 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 		w.Error(err, ctx.RequestID)
 		return
 	}
+	err = validation.ValidateEvaluationJobConfig(evaluation, h.providerConfigs)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
 
-	mlflowExperimentID, err := mlflow.GetExperimentID(ctx, h.mlflowClient, evaluation.Experiment)
+	dryRun, err := getParam(req, "dry_run", true, false)
 	if err != nil {
 		w.Error(err, ctx.RequestID)
 		return
 	}
+	if dryRun {
+		response, err := h.renderEvaluationJob(ctx, evaluation)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		w.WriteJSON(response, 200)
+		return
+	}
 
-	response, err := storage.CreateEvaluationJob(evaluation, mlflowExperimentID)
+	// a retried request returns the job that was created by the first one
+	idempotencyKey := req.Header("Idempotency-Key")
+	if idempotencyKey != "" {
+		existing, err := findRepeatedEvaluationJob(storage, evaluation, idempotencyKey)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		if existing != nil {
+			ctx.Logger.Info("Returning the evaluation job of a repeated request", "job_id", existing.Resource.ID, "idempotency_key", idempotencyKey)
+			w.WriteJSON(existing, 202)
+			return
+		}
+	}
+
+	response, err := h.submitEvaluationJob(ctx, storage, evaluation, idempotencyKey)
+	if err != nil && idempotencyKey != "" && hasMessageCode(err, messages.IdempotencyKeyConflict) {
+		// a concurrent request with the same key stored its job first
+		existing, findErr := findRepeatedEvaluationJob(storage, evaluation, idempotencyKey)
+		if findErr != nil {
+			w.Error(findErr, ctx.RequestID)
+			return
+		}
+		if existing != nil {
+			ctx.Logger.Info("Returning the evaluation job of a concurrent request", "job_id", existing.Resource.ID, "idempotency_key", idempotencyKey)
+			w.WriteJSON(existing, 202)
+			return
+		}
+	}
 	if err != nil {
 		w.Error(err, ctx.RequestID)
 		return
 	}
 
+	w.WriteJSON(response, 202)
+}
+
+// findRepeatedEvaluationJob returns the job created with the idempotency key, nil when there
+// is none. The key of a different request is a conflict. The job of a request that failed is
+// a conflict too, so that the client retries the job instead of getting the failure back.
+func findRepeatedEvaluationJob(storage abstractions.Storage, evaluation *api.EvaluationJobConfig, idempotencyKey string) (*api.EvaluationJobResource, error) {
+	existing, err := storage.FindEvaluationJobByIdempotencyKey(idempotencyKey)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	same, err := sameEvaluationJobConfig(&existing.EvaluationJobConfig, evaluation)
+	if err != nil {
+		return nil, err
+	}
+	if !same {
+		return nil, serviceerrors.NewServiceError(messages.IdempotencyKeyConflict, "IdempotencyKey", idempotencyKey)
+	}
+	if existing.Status != nil && existing.Status.State == api.OverallStateFailed {
+		return nil, serviceerrors.NewServiceError(messages.IdempotencyKeyJobFailed, "IdempotencyKey", idempotencyKey, "ResourceId", existing.Resource.ID)
+	}
+	return existing, nil
+}
+
+// renderEvaluationJob returns the resources that the runtime would create for the evaluation
+// job, the job is given a placeholder ID as it is never stored.
+func (h *Handlers) renderEvaluationJob(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig) (*api.EvaluationJobDryRun, error) {
+	response := &api.EvaluationJobDryRun{
+		EvaluationJobConfig: *evaluation,
+		Resources:           []api.RenderedResource{},
+	}
+	if h.runtime == nil {
+		return response, nil
+	}
+	job := &api.EvaluationJobResource{
+		Resource:            api.EvaluationResource{Resource: api.Resource{ID: dryRunJobID, CreatedAt: time.Now()}},
+		EvaluationJobConfig: *evaluation,
+	}
+	resources, err := h.runtime.WithLogger(ctx.Logger).WithContext(ctx.Ctx).RenderEvaluationJob(job)
+	if err != nil {
+		ctx.Logger.Info("Failed to render the evaluation job", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.EvaluationJobRenderFailed, "Error", err.Error())
+	}
+	response.Resources = append(response.Resources, resources...)
+	return response, nil
+}
+
+// sameEvaluationJobConfig compares the configurations by their JSON so that the stored
+// configuration, which went through a JSON round trip, matches the same request body.
+func sameEvaluationJobConfig(stored *api.EvaluationJobConfig, requested *api.EvaluationJobConfig) (bool, error) {
+	storedJSON, err := json.Marshal(stored)
+	if err != nil {
+		return false, err
+	}
+	requestedJSON, err := json.Marshal(requested)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(storedJSON, requestedJSON), nil
+}
+
+// submitEvaluationJob stores the evaluation job and hands it to the runtime, a job that the
+// runtime fails to start is marked as failed before the error is returned.
+func (h *Handlers) submitEvaluationJob(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, evaluation *api.EvaluationJobConfig, idempotencyKey string) (*api.EvaluationJobResource, error) {
+	mlflowExperimentID, err := mlflow.GetExperimentID(ctx, h.mlflowClient, evaluation.Experiment)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := storage.CreateEvaluationJob(evaluation, mlflowExperimentID, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
 	if h.runtime != nil {
-		job := response
 		runErr := executeEvaluationJob(ctx, h.runtime, job, &storage)
+		if runErr != nil && benchmarksStillSubmitted(runErr, evaluation.Benchmarks) {
+			// the failed benchmarks are recorded on the job, which goes on with the others
+			ctx.Logger.Warn("some benchmarks could not be submitted", "error", runErr, "job_id", job.Resource.ID)
+			return storage.GetEvaluationJob(job.Resource.ID)
+		}
 		if runErr != nil {
 			ctx.Logger.Error("RunEvaluationJob failed", "error", runErr, "job_id", job.Resource.ID)
 			state := api.OverallStateFailed
@@ -103,12 +269,11 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 			if err := storage.UpdateEvaluationJobStatus(job.Resource.ID, state, message); err != nil {
 				ctx.Logger.Error("failed to update evaluation status", "error", err, "job_id", job.Resource.ID)
 			}
-			w.Error(runErr, ctx.RequestID)
-			return
+			return nil, runErr
 		}
 	}
 
-	w.WriteJSON(response, 202)
+	return job, nil
 }
 
 func executeEvaluationJob(ctx *executioncontext.ExecutionContext, runtime abstractions.Runtime, job *api.EvaluationJobResource, storage *abstractions.Storage) (err error) {
@@ -121,7 +286,19 @@ func executeEvaluationJob(ctx *executioncontext.ExecutionContext, runtime abstra
 	return runtime.WithLogger(ctx.Logger).WithContext(ctx.Ctx).RunEvaluationJob(job, storage)
 }
 
+// benchmarksStillSubmitted reports whether the error of RunEvaluationJob only holds the
+// submission failures of some of the benchmarks, the runtime recorded them as failed and
+// the other benchmarks were submitted.
+func benchmarksStillSubmitted(runErr error, benchmarks []api.BenchmarkConfig) bool {
+	failures, ok := abstractions.BenchmarkErrors(runErr)
+	return ok && len(failures) < len(benchmarks)
+}
+
 // HandleListEvaluations handles GET /api/v1/evaluations/jobs
+//
+// The newest jobs are listed first. A list without a cursor is paged by offset, a list with
+// a cursor, empty for the first page, continues after the last job of the previous page and
+// only its pages return the cursor of the next page.
 func (h *Handlers) HandleListEvaluations(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
 
@@ -137,12 +314,57 @@ func (h *Handlers) HandleListEvaluations(ctx *executioncontext.ExecutionContext,
 		w.Error(err, ctx.RequestID)
 		return
 	}
-	statusFilter, err := getParam(r, "status_filter", true, "")
+	cursor, err := getParam(r, "cursor", true, "")
 	if err != nil {
 		w.Error(err, ctx.RequestID)
 		return
 	}
-	res, err := storage.GetEvaluationJobs(limit, offset, statusFilter)
+	paged := len(r.Query("cursor")) > 0
+	if paged && len(r.Query("offset")) > 0 {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParametersExclusive, "ParameterName", "offset", "OtherParameterName", "cursor"), ctx.RequestID)
+		return
+	}
+	filter, err := getEvaluationJobFilter(r)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	if paged {
+		if cursor != "" {
+			last, err := decodeCursor(cursor)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return
+			}
+			filter.After = &abstractions.EvaluationJobCursor{CreatedAt: last.LastCreatedAt, ID: last.LastID}
+		}
+		// one more job than the page tells whether there is a next page
+		res, err := storage.GetEvaluationJobs(limit+1, 0, filter)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		items, nextCursor := res.Items, ""
+		if len(items) > limit {
+			items = items[:limit]
+			if limit > 0 {
+				nextCursor = encodeCursor(items[limit-1].Resource.CreatedAt, items[limit-1].Resource.ID)
+			}
+		}
+		page, err := CreateCursorPage(res.TotalStored, limit, nextCursor, ctx, r)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		w.WriteJSON(api.EvaluationJobResourceList{
+			Page:  *page,
+			Items: items,
+		}, 200)
+		return
+	}
+
+	res, err := storage.GetEvaluationJobs(limit, offset, filter)
 	if err != nil {
 		w.Error(err, ctx.RequestID)
 		return
@@ -179,6 +401,26 @@ func (h *Handlers) HandleGetEvaluation(ctx *executioncontext.ExecutionContext, r
 	w.WriteJSON(response, 200)
 }
 
+// HandleGetEvaluationSummary handles GET /api/v1/evaluations/jobs/{id}/summary
+func (h *Handlers) HandleGetEvaluationSummary(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	job, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(api.NewSummaryResponse(job), 200)
+}
+
 func (h *Handlers) HandleUpdateEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
 	logging.LogRequestStarted(ctx)
@@ -230,6 +472,14 @@ func (h *Handlers) HandleCancelEvaluation(ctx *executioncontext.ExecutionContext
 		return
 	}
 
+	if h.runtime != nil {
+		if err := h.runtime.WithLogger(ctx.Logger).WithContext(ctx.Ctx).CancelEvaluationJob(evaluationJobID); err != nil {
+			ctx.Logger.Error("Failed to cancel evaluation job in runtime", "error", err.Error(), "id", evaluationJobID)
+			w.Error(err, ctx.RequestID)
+			return
+		}
+	}
+
 	err = storage.DeleteEvaluationJob(evaluationJobID, hardDelete)
 	if err != nil {
 		ctx.Logger.Info("Failed to delete evaluation job", "error", err.Error(), "id", evaluationJobID, "hardDelete", hardDelete)
@@ -238,3 +488,181 @@ func (h *Handlers) HandleCancelEvaluation(ctx *executioncontext.ExecutionContext
 	}
 	w.WriteJSON(nil, 204)
 }
+
+// HandleCancelBenchmark handles DELETE /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_id}
+//
+// The workload of the benchmark is removed and the benchmark is recorded as cancelled, the
+// other benchmarks of the job keep running. A benchmark that already finished is left as it is.
+func (h *Handlers) HandleCancelBenchmark(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+	benchmarkID := r.PathValue(constants.PATH_PARAMETER_BENCHMARK_ID)
+	if benchmarkID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_ID), ctx.RequestID)
+		return
+	}
+
+	job, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	var benchmark *api.BenchmarkConfig
+	for i := range job.Benchmarks {
+		if job.Benchmarks[i].ID == benchmarkID {
+			benchmark = &job.Benchmarks[i]
+			break
+		}
+	}
+	if benchmark == nil {
+		w.Error(serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "benchmark", "ResourceId", benchmarkID), ctx.RequestID)
+		return
+	}
+	if benchmarkFinished(job, benchmarkID) {
+		w.WriteJSON(nil, 204)
+		return
+	}
+
+	if h.runtime != nil {
+		if err := h.runtime.WithLogger(ctx.Logger).WithContext(ctx.Ctx).CancelBenchmark(evaluationJobID, benchmarkID); err != nil {
+			ctx.Logger.Error("Failed to cancel benchmark in runtime", "error", err.Error(), "id", evaluationJobID, "benchmark_id", benchmarkID)
+			w.Error(err, ctx.RequestID)
+			return
+		}
+	}
+
+	err = storage.UpdateEvaluationJob(evaluationJobID, &api.StatusEvent{
+		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID: benchmark.ProviderID,
+			ID:         benchmarkID,
+			Status:     api.StateCancelled,
+			Message:    &api.MessageInfo{Message: "Benchmark cancelled", MessageCode: constants.MESSAGE_CODE_BENCHMARK_CANCELLED},
+		},
+	})
+	if err != nil {
+		ctx.Logger.Error("Failed to mark benchmark as cancelled", "error", err.Error(), "id", evaluationJobID, "benchmark_id", benchmarkID)
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	w.WriteJSON(nil, 204)
+}
+
+// benchmarkFinished reports whether the benchmark of the job completed, failed or was
+// cancelled, together with the rest of the job or on its own.
+func benchmarkFinished(job *api.EvaluationJobResource, benchmarkID string) bool {
+	if job.Status == nil {
+		return false
+	}
+	if job.Status.State == api.OverallStateCancelled {
+		return true
+	}
+	for _, status := range job.Status.Benchmarks {
+		if status.ID != benchmarkID {
+			continue
+		}
+		switch status.Status {
+		case api.StateCompleted, api.StateFailed, api.StateCancelled:
+			return true
+		}
+	}
+	return false
+}
+
+// HandleRetryEvaluation handles POST /api/v1/evaluations/jobs/{id}/retry
+//
+// The failed benchmarks of the job are set back to pending and run again, the results of
+// the other benchmarks are kept. A cancelled job is not retried.
+func (h *Handlers) HandleRetryEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	job, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	// resetting the benchmarks would move a cancelled job back to pending
+	if job.Status != nil && job.Status.State == api.OverallStateCancelled {
+		w.Error(serviceerrors.NewServiceError(messages.EvaluationJobCancelledNotRetried, "ResourceId", evaluationJobID), ctx.RequestID)
+		return
+	}
+	benchmarks := failedBenchmarks(job)
+	if len(benchmarks) == 0 {
+		w.Error(serviceerrors.NewServiceError(messages.EvaluationJobNothingToRetry, "ResourceId", evaluationJobID), ctx.RequestID)
+		return
+	}
+	benchmarkIDs := make([]string, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		benchmarkIDs = append(benchmarkIDs, benchmark.ID)
+	}
+	if err := storage.ResetEvaluationJobBenchmarks(evaluationJobID, benchmarkIDs); err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	if h.runtime != nil {
+		retried := *job
+		retried.Benchmarks = benchmarks
+		runErr := executeEvaluationJob(ctx, h.runtime, &retried, &storage)
+		if runErr != nil && benchmarksStillSubmitted(runErr, benchmarks) {
+			ctx.Logger.Warn("some benchmarks could not be submitted", "error", runErr, "job_id", evaluationJobID)
+		} else if runErr != nil {
+			ctx.Logger.Error("RunEvaluationJob failed", "error", runErr, "job_id", evaluationJobID)
+			for i := range benchmarks {
+				status := &api.StatusEvent{
+					BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+						ProviderID:   benchmarks[i].ProviderID,
+						ID:           benchmarks[i].ID,
+						Status:       api.StateFailed,
+						ErrorMessage: &api.MessageInfo{Message: runErr.Error(), MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED},
+					},
+				}
+				if err := storage.UpdateEvaluationJob(evaluationJobID, status); err != nil {
+					ctx.Logger.Error("failed to update benchmark status", "error", err, "job_id", evaluationJobID, "benchmark_id", benchmarks[i].ID)
+				}
+			}
+			w.Error(runErr, ctx.RequestID)
+			return
+		}
+	}
+
+	response, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	w.WriteJSON(response, 202)
+}
+
+// failedBenchmarks returns the configuration of the benchmarks of the job whose status is
+// failed, in the order of the job.
+func failedBenchmarks(job *api.EvaluationJobResource) []api.BenchmarkConfig {
+	if job.Status == nil {
+		return nil
+	}
+	failed := map[string]bool{}
+	for _, status := range job.Status.Benchmarks {
+		if status.Status == api.StateFailed {
+			failed[status.ID] = true
+		}
+	}
+	var benchmarks []api.BenchmarkConfig
+	for _, benchmark := range job.Benchmarks {
+		if failed[benchmark.ID] {
+			benchmarks = append(benchmarks, benchmark)
+		}
+	}
+	return benchmarks
+}
@@ -2,20 +2,28 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
 	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/handlers"
+	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
 	"github.com/eval-hub/eval-hub/pkg/api"
 	"github.com/go-playground/validator/v10"
 )
 
+var testProviders = map[string]api.ProviderResource{"garak": {ProviderID: "garak"}}
+
 type bodyRequest struct {
 	*MockRequest
 	body    []byte
@@ -33,6 +41,21 @@ type fakeStorage struct {
 	abstractions.Storage
 	lastStatusID string
 	lastStatus   api.OverallState
+	deletedID    string
+	collection   *api.CollectionResource
+	created      *api.EvaluationJobConfig
+	statusCounts map[api.OverallState]int
+	job          *api.EvaluationJobResource
+	createdKey   string
+	keyedJob     *api.EvaluationJobResource
+	reset        []string
+	filter       abstractions.EvaluationJobFilter
+	pingErr      error
+	updates      []*api.StatusEvent
+	// jobs are listed by GetEvaluationJobs, in the order of the storage
+	jobs []api.EvaluationJobResource
+	// racedJob is stored with the key by a concurrent request while the job is created
+	racedJob *api.EvaluationJobResource
 }
 
 func (f *fakeStorage) WithLogger(_ *slog.Logger) abstractions.Storage { return f }
@@ -40,28 +63,61 @@ func (f *fakeStorage) WithContext(_ context.Context) abstractions.Storage {
 	return f
 }
 func (f *fakeStorage) GetDatasourceName() string  { return "fake" }
-func (f *fakeStorage) Ping(_ time.Duration) error { return nil }
-func (f *fakeStorage) CreateEvaluationJob(_ *api.EvaluationJobConfig, _ string) (*api.EvaluationJobResource, error) {
+func (f *fakeStorage) Ping(_ time.Duration) error { return f.pingErr }
+func (f *fakeStorage) CreateEvaluationJob(evaluation *api.EvaluationJobConfig, _ string, idempotencyKey string) (*api.EvaluationJobResource, error) {
+	if f.racedJob != nil {
+		f.keyedJob = f.racedJob
+		return nil, serviceerrors.NewServiceError(messages.IdempotencyKeyConflict, "IdempotencyKey", idempotencyKey)
+	}
+	f.created = evaluation
+	f.createdKey = idempotencyKey
 	return &api.EvaluationJobResource{
 		Resource: api.EvaluationResource{
 			Resource: api.Resource{ID: "job-1"},
 		},
 	}, nil
 }
-func (f *fakeStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) { return nil, nil }
-func (f *fakeStorage) GetEvaluationJobs(_ int, _ int, _ string) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
-	return nil, nil
+func (f *fakeStorage) FindEvaluationJobByIdempotencyKey(_ string) (*api.EvaluationJobResource, error) {
+	return f.keyedJob, nil
+}
+func (f *fakeStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
+	return f.job, nil
+}
+func (f *fakeStorage) GetEvaluationJobs(limit int, offset int, filter abstractions.EvaluationJobFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	f.filter = filter
+	var items []api.EvaluationJobResource
+	for _, job := range f.jobs {
+		after := filter.After
+		if after == nil || job.Resource.CreatedAt.Before(after.CreatedAt) || (job.Resource.CreatedAt.Equal(after.CreatedAt) && job.Resource.ID < after.ID) {
+			items = append(items, job)
+		}
+	}
+	items = items[min(offset, len(items)):]
+	return &abstractions.QueryResults[api.EvaluationJobResource]{Items: items[:min(limit, len(items))], TotalStored: len(f.jobs)}, nil
+}
+func (f *fakeStorage) DeleteEvaluationJob(id string, _ bool) error {
+	f.deletedID = id
+	return nil
 }
-func (f *fakeStorage) DeleteEvaluationJob(_ string, _ bool) error { return nil }
 func (f *fakeStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) error {
 	f.lastStatusID = id
 	f.lastStatus = state
 	return nil
 }
-func (f *fakeStorage) UpdateEvaluationJob(_ string, _ *api.StatusEvent) error { return nil }
-func (f *fakeStorage) CreateCollection(_ *api.CollectionResource) error       { return nil }
+func (f *fakeStorage) UpdateEvaluationJob(_ string, runStatus *api.StatusEvent) error {
+	f.updates = append(f.updates, runStatus)
+	return nil
+}
+func (f *fakeStorage) ResetEvaluationJobBenchmarks(_ string, benchmarkIDs []string) error {
+	f.reset = benchmarkIDs
+	return nil
+}
+func (f *fakeStorage) CountEvaluationJobsByStatus() (map[api.OverallState]int, error) {
+	return f.statusCounts, nil
+}
+func (f *fakeStorage) CreateCollection(_ *api.CollectionResource) error { return nil }
 func (f *fakeStorage) GetCollection(_ string, _ bool) (*api.CollectionResource, error) {
-	return nil, nil
+	return f.collection, nil
 }
 func (f *fakeStorage) GetCollections(_ int, _ int) (*abstractions.QueryResults[api.CollectionResource], error) {
 	return nil, nil
@@ -71,8 +127,19 @@ func (f *fakeStorage) DeleteCollection(_ string) error                  { return
 func (f *fakeStorage) Close() error                                     { return nil }
 
 type fakeRuntime struct {
-	err    error
-	called bool
+	err         error
+	called      bool
+	cancelErr   error
+	cancelledID string
+	active      int
+	logs        string
+	logsErr     error
+	logOptions  abstractions.LogOptions
+	rendered    *api.EvaluationJobResource
+	renderErr   error
+	ran         *api.EvaluationJobResource
+	// cancelledBenchmarks are the benchmark IDs passed to CancelBenchmark
+	cancelledBenchmarks []string
 }
 
 func (r *fakeRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime { return r }
@@ -80,17 +147,47 @@ func (r *fakeRuntime) WithContext(_ context.Context) abstractions.Runtime {
 	return r
 }
 func (r *fakeRuntime) Name() string { return "fake" }
-func (r *fakeRuntime) RunEvaluationJob(_ *api.EvaluationJobResource, _ *abstractions.Storage) error {
+func (r *fakeRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, _ *abstractions.Storage) error {
 	r.called = true
+	r.ran = evaluation
 	return r.err
 }
+func (r *fakeRuntime) RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error) {
+	r.rendered = evaluation
+	if r.renderErr != nil {
+		return nil, r.renderErr
+	}
+	resources := []api.RenderedResource{}
+	for _, benchmark := range evaluation.Benchmarks {
+		resources = append(resources, api.RenderedResource{Kind: "Job", Name: evaluation.Resource.ID + "-" + benchmark.ID, ProviderID: benchmark.ProviderID, BenchmarkID: benchmark.ID})
+	}
+	return resources, nil
+}
+func (r *fakeRuntime) CancelEvaluationJob(jobID string) error {
+	r.cancelledID = jobID
+	return r.cancelErr
+}
+func (r *fakeRuntime) CancelBenchmark(_ string, benchmarkID string) error {
+	r.cancelledBenchmarks = append(r.cancelledBenchmarks, benchmarkID)
+	return r.cancelErr
+}
+
+func (r *fakeRuntime) CountActiveJobs() (int, error)    { return r.active, nil }
+func (r *fakeRuntime) Shutdown(_ context.Context) error { return nil }
+func (r *fakeRuntime) StreamBenchmarkLogs(_ string, _ string, options abstractions.LogOptions) (io.ReadCloser, error) {
+	r.logOptions = options
+	if r.logsErr != nil {
+		return nil, r.logsErr
+	}
+	return io.NopCloser(strings.NewReader(r.logs)), nil
+}
 
 func TestHandleCreateEvaluationMarksFailedWhenRuntimeErrors(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	storage := &fakeStorage{}
 	runtime := &fakeRuntime{err: errors.New("runtime failed")}
 	validate := validator.New()
-	h := handlers.New(storage, validate, runtime, nil, nil, nil)
+	h := handlers.New(storage, validate, runtime, nil, testProviders, nil)
 	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logger, time.Second)
 
 	req := &bodyRequest{
@@ -124,7 +221,7 @@ func TestHandleCreateEvaluationSucceedsWhenRuntimeOk(t *testing.T) {
 	storage := &fakeStorage{}
 	runtime := &fakeRuntime{}
 	validate := validator.New()
-	h := handlers.New(storage, validate, runtime, nil, nil, nil)
+	h := handlers.New(storage, validate, runtime, nil, testProviders, nil)
 	ctx := executioncontext.NewExecutionContext(context.Background(), "req-2", logger, time.Second)
 
 	req := &bodyRequest{
@@ -146,3 +243,615 @@ func TestHandleCreateEvaluationSucceedsWhenRuntimeOk(t *testing.T) {
 		t.Fatalf("expected status 202, got %d", recorder.Code)
 	}
 }
+
+func TestHandleCreateEvaluationKeepsJobWhenSomeBenchmarksFail(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{job: &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+		Status: &api.EvaluationJobStatus{Benchmarks: []api.BenchmarkStatus{
+			{ProviderID: "garak", ID: "bench-1", Status: api.StatePending},
+			{ProviderID: "garak", ID: "bench-2", Status: api.StateFailed},
+		}},
+	}}
+	runtime := &fakeRuntime{err: errors.Join(&abstractions.BenchmarkError{
+		ProviderID:  "garak",
+		BenchmarkID: "bench-2",
+		Err:         errors.New("exceeded quota"),
+	})}
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-partial", logger, time.Second)
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(`{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"},{"id":"bench-2","provider_id":"garak"}]}`),
+	}
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if storage.lastStatus != "" {
+		t.Fatalf("did not expect the job to be marked %s", storage.lastStatus)
+	}
+	job := &api.EvaluationJobResource{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), job); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+	if job.Status == nil || len(job.Status.Benchmarks) != 2 || job.Status.Benchmarks[1].Status != api.StateFailed {
+		t.Fatalf("expected the stored benchmark statuses in the response, got %+v", job.Status)
+	}
+}
+
+func TestHandleCreateEvaluationReportsEveryInvalidField(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	runtime := &fakeRuntime{}
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-invalid", logger, time.Second)
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(`{"model":{"url":"not a url","name":""},"benchmarks":[{"id":"bench-1","provider_id":"garak"},{"id":"bench-2","provider_id":"unknown"}]}`),
+	}
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+
+	h.HandleCreateEvaluation(ctx, req, resp)
+
+	if recorder.Code != constants.HTTPCodeUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", recorder.Code)
+	}
+	for _, field := range []string{"model.name", "model.url", "benchmarks[1].provider_id"} {
+		if !strings.Contains(recorder.Body.String(), field) {
+			t.Fatalf("expected %s to be reported, got %s", field, recorder.Body.String())
+		}
+	}
+	if strings.Contains(recorder.Body.String(), "benchmarks[0]") {
+		t.Fatalf("did not expect the valid benchmark to be reported, got %s", recorder.Body.String())
+	}
+	if runtime.called || storage.created != nil {
+		t.Fatalf("expected no evaluation job to be created")
+	}
+}
+
+func TestHandleCreateEvaluationDryRunRendersWithoutSubmitting(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	runtime := &fakeRuntime{}
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-dry-run", logger, time.Second)
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(`{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`),
+	}
+	req.SetQuery("dry_run", "true")
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	if runtime.called || storage.created != nil {
+		t.Fatalf("expected no evaluation job to be stored or submitted")
+	}
+	if runtime.rendered == nil || len(runtime.rendered.Benchmarks) != 1 {
+		t.Fatalf("expected the evaluation job to be rendered")
+	}
+	response := api.EvaluationJobDryRun{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+	if len(response.Resources) != 1 || response.Resources[0].BenchmarkID != "bench-1" || response.Model.Name != "test" {
+		t.Fatalf("unexpected dry run response %+v", response)
+	}
+}
+
+func TestHandleCreateEvaluationDryRunReportsRenderFailure(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	runtime := &fakeRuntime{renderErr: errors.New("image is not set")}
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-dry-run", logger, time.Second)
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(`{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`),
+	}
+	req.SetQuery("dry_run", "true")
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != constants.HTTPCodeUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), "image is not set") || storage.created != nil {
+		t.Fatalf("expected the render error without a stored job, got %s", recorder.Body.String())
+	}
+}
+
+func createKeyedEvaluation(t *testing.T, storage *fakeStorage, runtime *fakeRuntime, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-keyed", logger, time.Second)
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(body),
+	}
+	req.SetHeader("Idempotency-Key", "key-1")
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+	return recorder
+}
+
+func TestHandleCreateEvaluationStoresIdempotencyKey(t *testing.T) {
+	storage := &fakeStorage{}
+	runtime := &fakeRuntime{}
+
+	recorder := createKeyedEvaluation(t, storage, runtime, `{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d", recorder.Code)
+	}
+	if storage.createdKey != "key-1" || !runtime.called {
+		t.Fatalf("expected the job to be created with the key and submitted, got key %q", storage.createdKey)
+	}
+}
+
+func TestHandleCreateEvaluationReturnsJobOfRepeatedRequest(t *testing.T) {
+	existing := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-original"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "bench-1"}, ProviderID: "garak"}},
+		},
+	}
+	storage := &fakeStorage{keyedJob: existing}
+	runtime := &fakeRuntime{}
+
+	recorder := createKeyedEvaluation(t, storage, runtime, `{"model":{"name":"test","url":"http://test.com"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d", recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), "job-original") {
+		t.Fatalf("expected the original job to be returned, got %s", recorder.Body.String())
+	}
+	if storage.created != nil || runtime.called {
+		t.Fatalf("expected no new job to be created or submitted")
+	}
+}
+
+func TestHandleCreateEvaluationRejectsReusedKeyWithDifferentBody(t *testing.T) {
+	existing := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-original"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "bench-1"}, ProviderID: "garak"}},
+		},
+	}
+	storage := &fakeStorage{keyedJob: existing}
+	runtime := &fakeRuntime{}
+
+	recorder := createKeyedEvaluation(t, storage, runtime, `{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-2","provider_id":"garak"}]}`)
+
+	if recorder.Code != constants.HTTPCodeConflict {
+		t.Fatalf("expected status 409, got %d", recorder.Code)
+	}
+	if storage.created != nil || runtime.called {
+		t.Fatalf("expected no new job to be created or submitted")
+	}
+}
+
+func TestHandleCreateEvaluationPointsRepeatedRequestOfFailedJobToRetry(t *testing.T) {
+	existing := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-original"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateFailed}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "bench-1"}, ProviderID: "garak"}},
+		},
+	}
+	storage := &fakeStorage{keyedJob: existing}
+	runtime := &fakeRuntime{}
+
+	recorder := createKeyedEvaluation(t, storage, runtime, `{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+
+	if recorder.Code != constants.HTTPCodeConflict {
+		t.Fatalf("expected status 409, got %d", recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), "/api/v1/evaluations/jobs/job-original/retry") {
+		t.Fatalf("expected the retry endpoint of the job, got %s", recorder.Body.String())
+	}
+	if storage.created != nil || runtime.called {
+		t.Fatalf("expected no new job to be created or submitted")
+	}
+}
+
+func TestHandleCreateEvaluationReturnsJobOfConcurrentRequest(t *testing.T) {
+	raced := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-concurrent"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "bench-1"}, ProviderID: "garak"}},
+		},
+	}
+	storage := &fakeStorage{racedJob: raced}
+	runtime := &fakeRuntime{}
+
+	recorder := createKeyedEvaluation(t, storage, runtime, `{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), "job-concurrent") {
+		t.Fatalf("expected the job of the concurrent request, got %s", recorder.Body.String())
+	}
+	if runtime.called {
+		t.Fatalf("expected the job of the concurrent request not to be submitted again")
+	}
+}
+
+func TestHandleCancelEvaluationCancelsRuntimeJob(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	runtime := &fakeRuntime{}
+	h := handlers.New(storage, validator.New(), runtime, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-3", logger, time.Second)
+
+	req := createMockRequest("DELETE", "/api/v1/evaluations/jobs/job-1")
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+
+	h.HandleCancelEvaluation(ctx, req, resp)
+
+	if runtime.cancelledID != "job-1" {
+		t.Fatalf("expected runtime cancel for job-1, got %q", runtime.cancelledID)
+	}
+	if storage.deletedID != "job-1" {
+		t.Fatalf("expected storage delete for job-1, got %q", storage.deletedID)
+	}
+	if recorder.Code != 204 {
+		t.Fatalf("expected 204, got %d", recorder.Code)
+	}
+}
+
+func TestHandleCancelEvaluationKeepsJobWhenRuntimeCancelFails(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	runtime := &fakeRuntime{cancelErr: errors.New("cancel failed")}
+	h := handlers.New(storage, validator.New(), runtime, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-4", logger, time.Second)
+
+	req := createMockRequest("DELETE", "/api/v1/evaluations/jobs/job-1")
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+
+	h.HandleCancelEvaluation(ctx, req, resp)
+
+	if storage.deletedID != "" {
+		t.Fatalf("expected job to be kept when the runtime cancel fails")
+	}
+	if recorder.Code == 204 {
+		t.Fatalf("expected an error response, got %d", recorder.Code)
+	}
+}
+
+func TestHandleGetEvaluationSummary(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	job := retryJob(api.StateCompleted, api.StateFailed)
+	job.Status.State = api.OverallStatePartiallyFailed
+	job.Results = &api.EvaluationJobResults{
+		Benchmarks: []api.BenchmarkResult{{ID: "bench-a", ProviderID: "garak", Metrics: map[string]any{"acc": 0.5}}},
+	}
+	h := handlers.New(&fakeStorage{job: job}, validator.New(), &fakeRuntime{}, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-summary", logger, time.Second)
+
+	req := createMockRequest("GET", "/api/v1/evaluations/jobs/job-1/summary")
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	recorder := httptest.NewRecorder()
+	h.HandleGetEvaluationSummary(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	summary := &api.SummaryResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), summary); err != nil {
+		t.Fatalf("failed to parse the summary: %v", err)
+	}
+	if summary.ID != "job-1" || summary.State != api.OverallStatePartiallyFailed || len(summary.Benchmarks) != 2 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.Benchmarks[0].Metrics["acc"] != 0.5 || summary.Benchmarks[1].Status != api.StateFailed {
+		t.Fatalf("unexpected benchmarks %+v", summary.Benchmarks)
+	}
+}
+
+func cancelBenchmark(t *testing.T, storage *fakeStorage, runtime *fakeRuntime, benchmarkID string) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-cancel-benchmark", logger, time.Second)
+
+	req := createMockRequest("DELETE", "/api/v1/evaluations/jobs/job-1/benchmarks/"+benchmarkID)
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	req.SetPathValue(constants.PATH_PARAMETER_BENCHMARK_ID, benchmarkID)
+	recorder := httptest.NewRecorder()
+	h.HandleCancelBenchmark(ctx, req, MockResponseWrapper{recorder: recorder})
+	return recorder
+}
+
+func TestHandleCancelBenchmarkCancelsOnlyThatBenchmark(t *testing.T) {
+	storage := &fakeStorage{job: retryJob(api.StateRunning, api.StateRunning)}
+	runtime := &fakeRuntime{}
+
+	recorder := cancelBenchmark(t, storage, runtime, "bench-b")
+	if recorder.Code != 204 {
+		t.Fatalf("expected status 204, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(runtime.cancelledBenchmarks) != 1 || runtime.cancelledBenchmarks[0] != "bench-b" {
+		t.Fatalf("expected only bench-b to be cancelled in the runtime, got %v", runtime.cancelledBenchmarks)
+	}
+	if runtime.cancelledID != "" || storage.deletedID != "" {
+		t.Fatalf("expected the job to keep running")
+	}
+	if len(storage.updates) != 1 {
+		t.Fatalf("expected a single status update, got %d", len(storage.updates))
+	}
+	update := storage.updates[0].BenchmarkStatusEvent
+	if update.ID != "bench-b" || update.ProviderID != "garak" || update.Status != api.StateCancelled {
+		t.Fatalf("expected bench-b to be marked cancelled, got %+v", update)
+	}
+}
+
+func TestHandleCancelBenchmarkIgnoresFinishedBenchmark(t *testing.T) {
+	for _, state := range []api.State{api.StateCompleted, api.StateFailed, api.StateCancelled} {
+		storage := &fakeStorage{job: retryJob(api.StateRunning, state)}
+		runtime := &fakeRuntime{}
+
+		recorder := cancelBenchmark(t, storage, runtime, "bench-b")
+		if recorder.Code != 204 {
+			t.Fatalf("expected status 204 for a %s benchmark, got %d: %s", state, recorder.Code, recorder.Body.String())
+		}
+		if len(runtime.cancelledBenchmarks) != 0 || len(storage.updates) != 0 {
+			t.Fatalf("expected a %s benchmark to be left as it is", state)
+		}
+	}
+}
+
+func TestHandleCancelBenchmarkUnknownBenchmark(t *testing.T) {
+	storage := &fakeStorage{job: retryJob(api.StateRunning)}
+	runtime := &fakeRuntime{}
+
+	recorder := cancelBenchmark(t, storage, runtime, "unknown")
+	if recorder.Code != 404 {
+		t.Fatalf("expected status 404, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(runtime.cancelledBenchmarks) != 0 || len(storage.updates) != 0 {
+		t.Fatalf("expected nothing to be cancelled")
+	}
+}
+
+func TestHandleCancelBenchmarkKeepsStatusWhenRuntimeCancelFails(t *testing.T) {
+	storage := &fakeStorage{job: retryJob(api.StateRunning)}
+	runtime := &fakeRuntime{cancelErr: errors.New("cancel failed")}
+
+	recorder := cancelBenchmark(t, storage, runtime, "bench-a")
+	if recorder.Code == 204 {
+		t.Fatalf("expected an error response, got %d", recorder.Code)
+	}
+	if len(storage.updates) != 0 {
+		t.Fatalf("expected the benchmark status to be kept when the runtime cancel fails")
+	}
+}
+
+func retryJob(statuses ...api.State) *api.EvaluationJobResource {
+	job := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+		Status:   &api.EvaluationJobStatus{},
+	}
+	for i, state := range statuses {
+		id := "bench-" + string(rune('a'+i))
+		job.Benchmarks = append(job.Benchmarks, api.BenchmarkConfig{Ref: api.Ref{ID: id}, ProviderID: "garak"})
+		job.Status.Benchmarks = append(job.Status.Benchmarks, api.BenchmarkStatus{ID: id, ProviderID: "garak", Status: state})
+	}
+	return job
+}
+
+func retryEvaluation(t *testing.T, storage *fakeStorage, runtime *fakeRuntime) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.New(storage, validator.New(), runtime, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-retry", logger, time.Second)
+
+	req := createMockRequest("POST", "/api/v1/evaluations/jobs/job-1/retry")
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	recorder := httptest.NewRecorder()
+	h.HandleRetryEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+	return recorder
+}
+
+func TestHandleRetryEvaluationRunsFailedBenchmarks(t *testing.T) {
+	storage := &fakeStorage{job: retryJob(api.StateCompleted, api.StateFailed, api.StateFailed)}
+	runtime := &fakeRuntime{}
+
+	recorder := retryEvaluation(t, storage, runtime)
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(storage.reset) != 2 || storage.reset[0] != "bench-b" || storage.reset[1] != "bench-c" {
+		t.Fatalf("expected the failed benchmarks to be reset, got %v", storage.reset)
+	}
+	if runtime.ran == nil || len(runtime.ran.Benchmarks) != 2 || runtime.ran.Benchmarks[0].ID != "bench-b" {
+		t.Fatalf("expected only the failed benchmarks to run, got %+v", runtime.ran)
+	}
+	if len(storage.job.Benchmarks) != 3 {
+		t.Fatalf("expected the stored job to keep its benchmarks")
+	}
+}
+
+func TestHandleRetryEvaluationWithoutFailedBenchmarks(t *testing.T) {
+	storage := &fakeStorage{job: retryJob(api.StateCompleted, api.StateRunning)}
+	runtime := &fakeRuntime{}
+
+	recorder := retryEvaluation(t, storage, runtime)
+	if recorder.Code != 409 {
+		t.Fatalf("expected status 409, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if runtime.called || storage.reset != nil {
+		t.Fatalf("expected nothing to be retried")
+	}
+}
+
+func TestHandleRetryEvaluationOfCancelledJob(t *testing.T) {
+	storage := &fakeStorage{job: retryJob(api.StateCompleted, api.StateFailed, api.StateCancelled)}
+	storage.job.Status.State = api.OverallStateCancelled
+	runtime := &fakeRuntime{}
+
+	recorder := retryEvaluation(t, storage, runtime)
+	if recorder.Code != 409 || !strings.Contains(recorder.Body.String(), "was cancelled") {
+		t.Fatalf("expected status 409 for a cancelled job, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if runtime.called || storage.reset != nil {
+		t.Fatalf("expected the cancelled job not to be retried")
+	}
+}
+
+func TestHandleListEvaluationsFilters(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	h := handlers.New(storage, validator.New(), &fakeRuntime{}, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-list", logger, time.Second)
+
+	req := createMockRequest("GET", "/api/v1/evaluations/jobs")
+	req.SetQuery("status_filter", "running")
+	req.SetQuery("model_name", "granite")
+	req.SetQuery("created_after", "2026-01-01T00:00:00Z")
+	req.SetQuery("created_before", "2026-02-01T12:00:00+02:00")
+	recorder := httptest.NewRecorder()
+	h.HandleListEvaluations(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	filter := storage.filter
+	if filter.Status != "running" || filter.ModelName != "granite" {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+	if filter.CreatedAfter == nil || !filter.CreatedAfter.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected created_after %v", filter.CreatedAfter)
+	}
+	if filter.CreatedBefore == nil || !filter.CreatedBefore.Equal(time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected created_before %v", filter.CreatedBefore)
+	}
+}
+
+func TestHandleListEvaluationsRejectsInvalidTimestamp(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.New(&fakeStorage{}, validator.New(), &fakeRuntime{}, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-list", logger, time.Second)
+
+	req := createMockRequest("GET", "/api/v1/evaluations/jobs")
+	req.SetQuery("created_after", "2026-01-01")
+	recorder := httptest.NewRecorder()
+	h.HandleListEvaluations(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 400 {
+		t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), "created_after") {
+		t.Fatalf("expected the parameter to be reported, got %s", recorder.Body.String())
+	}
+}
+
+func TestHandleListEvaluationsCursorPagination(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{}
+	// the newest jobs are listed first whatever their IDs
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	for i, id := range []string{"job-c", "job-e", "job-a", "job-d", "job-b"} {
+		createdAt := created.Add(-time.Duration(i) * time.Minute)
+		storage.jobs = append(storage.jobs, api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: id, CreatedAt: createdAt}}})
+	}
+	h := handlers.New(storage, validator.New(), &fakeRuntime{}, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-cursor", logger, time.Second)
+
+	list := func(query map[string]string) api.EvaluationJobResourceList {
+		req := createMockRequest("GET", "/api/v1/evaluations/jobs")
+		req.SetQuery("limit", "2")
+		for key, value := range query {
+			req.SetQuery(key, value)
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleListEvaluations(ctx, req, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		page := api.EvaluationJobResourceList{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+			t.Fatalf("failed to decode the response: %v", err)
+		}
+		return page
+	}
+	ids := func(page api.EvaluationJobResourceList) string {
+		var ids []string
+		for _, item := range page.Items {
+			ids = append(ids, item.Resource.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	// an offset page is continued by offset, it has no cursor
+	offsetPage := list(map[string]string{"offset": "0"})
+	if ids(offsetPage) != "job-c,job-e" || offsetPage.NextCursor != "" || offsetPage.Next == nil {
+		t.Fatalf("expected the offset page without a cursor, got %s %+v", ids(offsetPage), offsetPage.Page)
+	}
+
+	first := list(map[string]string{"cursor": ""})
+	if ids(first) != "job-c,job-e" || first.NextCursor == "" {
+		t.Fatalf("expected the first page with a cursor, got %s %q", ids(first), first.NextCursor)
+	}
+	// a job that is created meanwhile does not shift the next pages
+	newest := api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-0", CreatedAt: created.Add(time.Minute)}}}
+	storage.jobs = append([]api.EvaluationJobResource{newest}, storage.jobs...)
+	second := list(map[string]string{"cursor": first.NextCursor})
+	if ids(second) != "job-a,job-d" || second.NextCursor == "" || second.Next == nil || !strings.Contains(second.Next.Href, "cursor=") {
+		t.Fatalf("expected the second page with a cursor, got %s %+v", ids(second), second.Page)
+	}
+	last := list(map[string]string{"cursor": second.NextCursor})
+	if ids(last) != "job-b" || last.NextCursor != "" || last.Next != nil {
+		t.Fatalf("expected the last page without a cursor, got %s %+v", ids(last), last.Page)
+	}
+}
+
+func TestHandleListEvaluationsRejectsInvalidCursor(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.New(&fakeStorage{}, validator.New(), &fakeRuntime{}, nil, testProviders, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-cursor", logger, time.Second)
+
+	cases := map[string]map[string]string{
+		"not base64":        {"cursor": "not a cursor!"},
+		"not a cursor":      {"cursor": base64.RawURLEncoding.EncodeToString([]byte(`{"id":"job-1"}`))},
+		"empty id":          {"cursor": base64.RawURLEncoding.EncodeToString([]byte(`{"last_created_at":"2026-03-01T09:30:00Z","last_id":""}`))},
+		"no creation time":  {"cursor": base64.RawURLEncoding.EncodeToString([]byte(`{"last_id":"job-1"}`))},
+		"offset and cursor": {"cursor": base64.RawURLEncoding.EncodeToString([]byte(`{"last_created_at":"2026-03-01T09:30:00Z","last_id":"job-1"}`)), "offset": "0"},
+	}
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := createMockRequest("GET", "/api/v1/evaluations/jobs")
+			for key, value := range query {
+				req.SetQuery(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			h.HandleListEvaluations(ctx, req, MockResponseWrapper{recorder: recorder})
+			if recorder.Code != 400 {
+				t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
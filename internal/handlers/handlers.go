@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/eval-hub/eval-hub/internal/abstractions"
 	"github.com/eval-hub/eval-hub/internal/config"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -16,6 +18,7 @@ type Handlers struct {
 	mlflowClient    *mlflowclient.Client
 	providerConfigs map[string]api.ProviderResource
 	serviceConfig   *config.Config
+	startTime       time.Time
 }
 
 func New(storage abstractions.Storage, validate *validator.Validate, runtime abstractions.Runtime, mlflowClient *mlflowclient.Client, providerConfigs map[string]api.ProviderResource, serviceConfig *config.Config) *Handlers {
@@ -26,5 +29,6 @@ func New(storage abstractions.Storage, validate *validator.Validate, runtime abs
 		mlflowClient:    mlflowClient,
 		providerConfigs: providerConfigs,
 		serviceConfig:   serviceConfig,
+		startTime:       time.Now(),
 	}
 }
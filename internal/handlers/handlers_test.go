@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -37,6 +38,9 @@ type MockRequest struct {
 	TestMethod string
 	TestURI    string
 	headers    map[string]string
+	pathValues map[string]string
+	query      map[string][]string
+	ctx        context.Context
 }
 
 func (r *MockRequest) Method() string {
@@ -52,9 +56,26 @@ func (r *MockRequest) Path() string {
 }
 
 func (r *MockRequest) Query(key string) []string {
+	if values, ok := r.query[key]; ok {
+		return values
+	}
 	return make([]string, 0)
 }
 
+func (r *MockRequest) SetQuery(key string, values ...string) {
+	if r.query == nil {
+		r.query = make(map[string][]string)
+	}
+	r.query[key] = values
+}
+
+func (r *MockRequest) Context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
 func (r *MockRequest) Header(key string) string {
 	return r.headers[key]
 }
@@ -68,7 +89,14 @@ func (r *MockRequest) SetHeader(key string, value string) {
 }
 
 func (r *MockRequest) PathValue(name string) string {
-	return ""
+	return r.pathValues[name]
+}
+
+func (r *MockRequest) SetPathValue(name string, value string) {
+	if r.pathValues == nil {
+		r.pathValues = make(map[string]string)
+	}
+	r.pathValues[name] = value
 }
 
 type MockResponseWrapper struct {
@@ -91,6 +119,10 @@ func (w MockResponseWrapper) Write(buf []byte) (int, error) {
 	return w.recorder.Write(buf)
 }
 
+func (w MockResponseWrapper) Flush() {
+	w.recorder.Flush()
+}
+
 func (w MockResponseWrapper) Error(err error, requestId string) {
 	var e abstractions.ServiceError
 	if errors.As(err, &e) {
@@ -1,15 +1,83 @@
 package handlers
 
 import (
+	"context"
 	"time"
 
+	"github.com/eval-hub/eval-hub/internal/abstractions"
 	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/http_wrappers"
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
+// readinessCheckTimeout bounds every readiness check so that a hung dependency does not hang the probe
+const readinessCheckTimeout = 2 * time.Second
+
 func (h *Handlers) HandleHealth(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	w.WriteJSON(map[string]interface{}{
 		"status":    "healthy",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}, 200)
 }
+
+// readinessChecks returns the checks of the storage and of the runtimes that depend on other services.
+func (h *Handlers) readinessChecks() []abstractions.ReadinessCheck {
+	var checks []abstractions.ReadinessCheck
+	if h.storage != nil {
+		checks = append(checks, abstractions.ReadinessCheck{
+			Name: "storage",
+			Check: func(ctx context.Context) error {
+				return h.storage.WithContext(ctx).Ping(readinessCheckTimeout)
+			},
+		})
+	}
+	if checker, ok := h.runtime.(abstractions.ReadinessChecker); ok {
+		checks = append(checks, checker.ReadinessChecks()...)
+	}
+	return checks
+}
+
+// HandleReadiness handles GET /readyz, it runs the readiness checks concurrently and answers
+// 503 when any of them fails or does not finish in time.
+func (h *Handlers) HandleReadiness(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	checks := h.readinessChecks()
+	checkCtx, cancel := context.WithTimeout(ctx.Ctx, readinessCheckTimeout)
+	defer cancel()
+
+	type outcome struct {
+		name string
+		err  error
+	}
+	outcomes := make(chan outcome, len(checks))
+	response := api.ReadinessResponse{Status: "ready", Dependencies: map[string]api.DependencyStatus{}}
+	for _, check := range checks {
+		// a check that does not finish in time is reported as such
+		response.Dependencies[check.Name] = api.DependencyStatus{Status: "down", Error: "the check did not finish in time"}
+		go func() {
+			outcomes <- outcome{name: check.Name, err: check.Check(checkCtx)}
+		}()
+	}
+wait:
+	for range checks {
+		select {
+		case result := <-outcomes:
+			if result.err != nil {
+				ctx.Logger.Warn("readiness check failed", "dependency", result.name, "error", result.err)
+				response.Dependencies[result.name] = api.DependencyStatus{Status: "down", Error: result.err.Error()}
+			} else {
+				response.Dependencies[result.name] = api.DependencyStatus{Status: "up"}
+			}
+		case <-checkCtx.Done():
+			break wait
+		}
+	}
+
+	code := 200
+	for _, dependency := range response.Dependencies {
+		if dependency.Status != "up" {
+			response.Status = "not_ready"
+			code = 503
+		}
+	}
+	w.WriteJSON(response, code)
+}
@@ -1,13 +1,20 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 func TestHandleHealth(t *testing.T) {
@@ -50,3 +57,69 @@ func TestHandleHealth(t *testing.T) {
 	})
 
 }
+
+// checkedRuntime is a runtime whose dependency answers with err, or never when hang is set
+type checkedRuntime struct {
+	fakeRuntime
+	err  error
+	hang bool
+}
+
+func (r *checkedRuntime) ReadinessChecks() []abstractions.ReadinessCheck {
+	return []abstractions.ReadinessCheck{{
+		Name: "kubernetes",
+		Check: func(ctx context.Context) error {
+			if r.hang {
+				select {}
+			}
+			return r.err
+		},
+	}}
+}
+
+func readiness(t *testing.T, storage *fakeStorage, runtime abstractions.Runtime) (int, api.ReadinessResponse) {
+	t.Helper()
+	h := handlers.New(storage, nil, runtime, nil, nil, nil)
+	w := httptest.NewRecorder()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-ready", logger, 10*time.Second)
+	h.HandleReadiness(ctx, createMockRequest("GET", "/readyz"), &MockResponseWrapper{w})
+	var response api.ReadinessResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return w.Code, response
+}
+
+func TestHandleReadiness(t *testing.T) {
+	t.Run("every dependency up", func(t *testing.T) {
+		code, response := readiness(t, &fakeStorage{}, &checkedRuntime{})
+		if code != 200 || response.Status != "ready" {
+			t.Fatalf("Expected the service to be ready, got %d %+v", code, response)
+		}
+		if response.Dependencies["storage"].Status != "up" || response.Dependencies["kubernetes"].Status != "up" {
+			t.Fatalf("Expected both dependencies to be up, got %+v", response.Dependencies)
+		}
+	})
+
+	t.Run("storage down", func(t *testing.T) {
+		code, response := readiness(t, &fakeStorage{pingErr: errors.New("connection refused")}, &fakeRuntime{})
+		if code != 503 || response.Status != "not_ready" {
+			t.Fatalf("Expected the service not to be ready, got %d %+v", code, response)
+		}
+		if storage := response.Dependencies["storage"]; storage.Status != "down" || storage.Error != "connection refused" {
+			t.Fatalf("Expected the storage to be down, got %+v", storage)
+		}
+	})
+
+	t.Run("hung dependency times out", func(t *testing.T) {
+		started := time.Now()
+		code, response := readiness(t, &fakeStorage{}, &checkedRuntime{hang: true})
+		if elapsed := time.Since(started); elapsed > 5*time.Second {
+			t.Fatalf("Expected the probe to answer at the check timeout, took %s", elapsed)
+		}
+		if code != 503 || response.Dependencies["kubernetes"].Status != "down" || response.Dependencies["storage"].Status != "up" {
+			t.Fatalf("Expected only kubernetes to be down, got %d %+v", code, response)
+		}
+	})
+}
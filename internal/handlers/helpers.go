@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/http_wrappers"
@@ -39,3 +44,63 @@ func CreatePage(total int, offset int, limit int, ctx *executioncontext.Executio
 		TotalCount: total,
 	}, nil
 }
+
+// CreateCursorPage returns the page of a list read from a cursor, the next link carries the
+// cursor of the following page in place of the one of the request.
+func CreateCursorPage(total int, limit int, nextCursor string, ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper) (*api.Page, error) {
+	var nextHref *api.HRef
+	if nextCursor != "" {
+		href, err := url.Parse(r.URI())
+		if err != nil {
+			ctx.Logger.Error("Failed to parse request URI", "uri", r.URI(), "error", err)
+			return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+		}
+		q := href.Query()
+		q.Set("cursor", nextCursor)
+		href.RawQuery = q.Encode()
+		nextHref = &api.HRef{Href: href.String()}
+	}
+
+	return &api.Page{
+		First:      &api.HRef{Href: r.URI()},
+		Next:       nextHref,
+		Limit:      limit,
+		TotalCount: total,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// pageCursor is the content of the opaque cursor of a list, the position of the last item
+// that was returned.
+type pageCursor struct {
+	LastCreatedAt time.Time `json:"last_created_at"`
+	LastID        string    `json:"last_id"`
+}
+
+func encodeCursor(lastCreatedAt time.Time, lastID string) string {
+	cursor, _ := json.Marshal(&pageCursor{LastCreatedAt: lastCreatedAt, LastID: lastID})
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
+
+// decodeCursor returns the position of the last item of the previous page, a cursor that was
+// not returned by the service is rejected as an invalid query parameter.
+func decodeCursor(cursor string) (*pageCursor, error) {
+	invalid := serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", "cursor", "Type", "cursor", "Value", cursor)
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+	content := &pageCursor{}
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(content); err != nil || decoder.More() || content.LastID == "" || content.LastCreatedAt.IsZero() {
+		return nil, invalid
+	}
+	return content, nil
+}
+
+// hasMessageCode reports whether the error is a service error with the message code.
+func hasMessageCode(err error, code *messages.MessageCode) bool {
+	var serviceErr *serviceerrors.ServiceError
+	return errors.As(err, &serviceErr) && serviceErr.MessageCode() == code
+}
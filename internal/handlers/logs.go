@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
+)
+
+const logsBufferSize = 32 * 1024
+
+// HandleGetBenchmarkLogs handles GET /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_id}/logs
+//
+// The logs are written as plain text and flushed as they are read, with follow=true the
+// response stays open until the benchmark finishes or the client disconnects.
+func (h *Handlers) HandleGetBenchmarkLogs(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+	benchmarkID := r.PathValue(constants.PATH_PARAMETER_BENCHMARK_ID)
+	if benchmarkID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_ID), ctx.RequestID)
+		return
+	}
+	follow, err := getParam(r, "follow", true, false)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	// all the logs are returned without tailLines
+	var tailLines *int64
+	if len(r.Query("tailLines")) > 0 {
+		lines, err := getParam(r, "tailLines", true, 0)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		if lines < 0 {
+			w.Error(serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", "tailLines", "Type", "non-negative integer", "Value", lines), ctx.RequestID)
+			return
+		}
+		tail := int64(lines)
+		tailLines = &tail
+	}
+
+	job, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	found := false
+	for _, benchmark := range job.Benchmarks {
+		if benchmark.ID == benchmarkID {
+			found = true
+			break
+		}
+	}
+	if !found || h.runtime == nil {
+		w.Error(serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "benchmark", "ResourceId", benchmarkID), ctx.RequestID)
+		return
+	}
+
+	options := abstractions.LogOptions{Follow: follow, TailLines: tailLines}
+	// the stream is bound to the request so that it ends when the client disconnects
+	logs, err := h.runtime.WithLogger(ctx.Logger).WithContext(r.Context()).StreamBenchmarkLogs(evaluationJobID, benchmarkID, options)
+	if err != nil {
+		if errors.Is(err, abstractions.ErrBenchmarkNotFound) {
+			w.Error(serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "benchmark logs", "ResourceId", benchmarkID), ctx.RequestID)
+			return
+		}
+		ctx.Logger.Error("Failed to stream benchmark logs", "error", err, "job_id", evaluationJobID, "benchmark_id", benchmarkID)
+		w.Error(serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error()), ctx.RequestID)
+		return
+	}
+	defer logs.Close()
+
+	w.SetHeader("Content-Type", "text/plain; charset=utf-8")
+	w.SetHeader("X-Content-Type-Options", "nosniff")
+	w.SetStatusCode(200)
+	w.Flush()
+
+	buf := make([]byte, logsBufferSize)
+	for {
+		n, readErr := logs.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				ctx.Logger.Info("Client stopped reading benchmark logs", "error", err, "job_id", evaluationJobID, "benchmark_id", benchmarkID)
+				return
+			}
+			w.Flush()
+		}
+		if readErr != nil {
+			if readErr != io.EOF && r.Context().Err() == nil {
+				ctx.Logger.Error("Failed to read benchmark logs", "error", readErr, "job_id", evaluationJobID, "benchmark_id", benchmarkID)
+			}
+			break
+		}
+	}
+	logging.LogRequestSuccess(ctx, 200, nil)
+}
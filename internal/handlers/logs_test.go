@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/go-playground/validator/v10"
+)
+
+func getBenchmarkLogs(runtime *fakeRuntime, benchmarkID string, query map[string]string) *httptest.ResponseRecorder {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeStorage{
+		job: &api.EvaluationJobResource{
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "bench-1"}, ProviderID: "garak"}},
+			},
+		},
+	}
+	h := handlers.New(storage, validator.New(), runtime, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-logs", logger, time.Second)
+
+	req := createMockRequest("GET", "/api/v1/evaluations/jobs/job-1/benchmarks/"+benchmarkID+"/logs")
+	req.SetPathValue(constants.PATH_PARAMETER_JOB_ID, "job-1")
+	req.SetPathValue(constants.PATH_PARAMETER_BENCHMARK_ID, benchmarkID)
+	for key, value := range query {
+		req.SetQuery(key, value)
+	}
+	recorder := httptest.NewRecorder()
+	h.HandleGetBenchmarkLogs(ctx, req, MockResponseWrapper{recorder: recorder})
+	return recorder
+}
+
+func TestHandleGetBenchmarkLogsStreamsRuntimeLogs(t *testing.T) {
+	runtime := &fakeRuntime{logs: "line 1\nline 2\n"}
+	recorder := getBenchmarkLogs(runtime, "bench-1", map[string]string{"follow": "true", "tailLines": "10"})
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder.Body.String() != "line 1\nline 2\n" {
+		t.Fatalf("unexpected logs %q", recorder.Body.String())
+	}
+	if !recorder.Flushed {
+		t.Fatalf("expected the logs to be flushed")
+	}
+	if !runtime.logOptions.Follow || runtime.logOptions.TailLines == nil || *runtime.logOptions.TailLines != 10 {
+		t.Fatalf("unexpected log options %+v", runtime.logOptions)
+	}
+}
+
+func TestHandleGetBenchmarkLogsUnknownBenchmark(t *testing.T) {
+	recorder := getBenchmarkLogs(&fakeRuntime{}, "bench-2", nil)
+
+	if recorder.Code != 404 {
+		t.Fatalf("expected status 404, got %d", recorder.Code)
+	}
+}
+
+func TestHandleGetBenchmarkLogsWithoutWorkload(t *testing.T) {
+	runtime := &fakeRuntime{logsErr: fmt.Errorf("job job-1: %w", abstractions.ErrBenchmarkNotFound)}
+	recorder := getBenchmarkLogs(runtime, "bench-1", nil)
+
+	if recorder.Code != 404 {
+		t.Fatalf("expected status 404, got %d", recorder.Code)
+	}
+}
+
+func TestHandleGetBenchmarkLogsRejectsNegativeTailLines(t *testing.T) {
+	for _, tailLines := range []string{"-1", "-2", "-5"} {
+		recorder := getBenchmarkLogs(&fakeRuntime{}, "bench-1", map[string]string{"tailLines": tailLines})
+
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400 for tailLines %s, got %d", tailLines, recorder.Code)
+		}
+	}
+}
+
+func TestHandleGetBenchmarkLogsWithoutTailLines(t *testing.T) {
+	runtime := &fakeRuntime{}
+	recorder := getBenchmarkLogs(runtime, "bench-1", nil)
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	if runtime.logOptions.TailLines != nil {
+		t.Fatalf("expected all the logs without tailLines, got %d", *runtime.logOptions.TailLines)
+	}
+}
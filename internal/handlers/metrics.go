@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleGetSystemMetrics handles GET /api/v1/metrics/system
+func (h *Handlers) HandleGetSystemMetrics(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx)
+	logging.LogRequestStarted(ctx)
+
+	counts, err := storage.CountEvaluationJobsByStatus()
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	runtimeMetrics := api.RuntimeMetrics{}
+	if h.runtime != nil {
+		runtimeMetrics.Name = h.runtime.Name()
+		runtimeMetrics.ActiveJobs, err = h.runtime.WithLogger(ctx.Logger).WithContext(ctx.Ctx).CountActiveJobs()
+		if err != nil {
+			ctx.Logger.Error("Failed to count active runtime jobs", "error", err)
+			w.Error(serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error()), ctx.RequestID)
+			return
+		}
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	now := time.Now()
+
+	w.WriteJSON(api.SystemMetrics{
+		Timestamp:      now.UTC(),
+		EvaluationJobs: evaluationJobCounts(counts),
+		Runtime:        runtimeMetrics,
+		Process: api.ProcessMetrics{
+			StartTime:      h.startTime.UTC(),
+			UptimeSeconds:  int64(now.Sub(h.startTime).Seconds()),
+			Goroutines:     runtime.NumGoroutine(),
+			HeapAllocBytes: memStats.HeapAlloc,
+		},
+	}, 200)
+}
+
+func evaluationJobCounts(counts map[api.OverallState]int) api.EvaluationJobCounts {
+	jobCounts := api.EvaluationJobCounts{
+		Pending:         counts[api.OverallStatePending],
+		Running:         counts[api.OverallStateRunning],
+		Completed:       counts[api.OverallStateCompleted],
+		Failed:          counts[api.OverallStateFailed],
+		Cancelled:       counts[api.OverallStateCancelled],
+		PartiallyFailed: counts[api.OverallStatePartiallyFailed],
+	}
+	for _, count := range counts {
+		jobCounts.Total += count
+	}
+	return jobCounts
+}
@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestHandleGetSystemMetrics(t *testing.T) {
+	storage := &fakeStorage{
+		statusCounts: map[api.OverallState]int{
+			api.OverallStatePending: 2,
+			api.OverallStateRunning: 1,
+			api.OverallStateFailed:  3,
+		},
+	}
+	runtime := &fakeRuntime{active: 4}
+	h := handlers.New(storage, validator.New(), runtime, nil, nil, nil)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-metrics", logger, time.Second)
+
+	w := httptest.NewRecorder()
+	h.HandleGetSystemMetrics(ctx, createMockRequest("GET", "/api/v1/metrics/system"), &MockResponseWrapper{w})
+
+	if w.Code != 200 {
+		t.Fatalf("Expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var metrics api.SystemMetrics
+	if err := json.Unmarshal(w.Body.Bytes(), &metrics); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	expected := api.EvaluationJobCounts{Total: 6, Pending: 2, Running: 1, Failed: 3}
+	if metrics.EvaluationJobs != expected {
+		t.Errorf("Expected job counts %+v, got %+v", expected, metrics.EvaluationJobs)
+	}
+	if metrics.Runtime.Name != "fake" || metrics.Runtime.ActiveJobs != 4 {
+		t.Errorf("Unexpected runtime metrics %+v", metrics.Runtime)
+	}
+	if metrics.Process.Goroutines == 0 || metrics.Process.HeapAllocBytes == 0 || metrics.Process.StartTime.IsZero() {
+		t.Errorf("Expected process metrics to be set, got %+v", metrics.Process)
+	}
+}
@@ -1,6 +1,10 @@
 package http_wrappers
 
-import "github.com/eval-hub/eval-hub/internal/messages"
+import (
+	"context"
+
+	"github.com/eval-hub/eval-hub/internal/messages"
+)
 
 // RequestWrapper abstracts the underlying HTTP request.
 type RequestWrapper interface {
@@ -12,6 +16,8 @@ type RequestWrapper interface {
 	Query(key string) []string
 	BodyAsBytes() ([]byte, error)
 	PathValue(name string) string
+	// Context is cancelled when the client disconnects
+	Context() context.Context
 }
 
 // Response abstraction of underlying HTTP library
@@ -23,4 +29,6 @@ type ResponseWrapper interface {
 	SetStatusCode(code int)
 	Write(buf []byte) (n int, err error)
 	WriteJSON(v any, code int)
+	// Flush sends the data written so far to the client
+	Flush()
 }
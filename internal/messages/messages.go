@@ -25,6 +25,48 @@ var (
 		"The {{.Type}} resource {{.ResourceId}} was not found.",
 	)
 
+	// ResourceAlreadyExists The {{.Type}} resource {{.ResourceId}} already exists.
+	ResourceAlreadyExists = createMessage(
+		constants.HTTPCodeConflict,
+		"The {{.Type}} resource {{.ResourceId}} already exists.",
+	)
+
+	// IdempotencyKeyConflict The idempotency key '{{.IdempotencyKey}}' was already used by a different request.
+	IdempotencyKeyConflict = createMessage(
+		constants.HTTPCodeConflict,
+		"The idempotency key '{{.IdempotencyKey}}' was already used by a different request.",
+	)
+
+	// IdempotencyKeyJobFailed The evaluation job {{.ResourceId}} created with the idempotency key '{{.IdempotencyKey}}' failed, retry it with POST /api/v1/evaluations/jobs/{{.ResourceId}}/retry.
+	IdempotencyKeyJobFailed = createMessage(
+		constants.HTTPCodeConflict,
+		"The evaluation job {{.ResourceId}} created with the idempotency key '{{.IdempotencyKey}}' failed, retry it with POST /api/v1/evaluations/jobs/{{.ResourceId}}/retry.",
+	)
+
+	// EvaluationJobNothingToRetry The evaluation job {{.ResourceId}} has no failed benchmarks to retry.
+	EvaluationJobNothingToRetry = createMessage(
+		constants.HTTPCodeConflict,
+		"The evaluation job {{.ResourceId}} has no failed benchmarks to retry.",
+	)
+
+	// EvaluationJobCancelledNotRetried The evaluation job {{.ResourceId}} was cancelled and can not be retried.
+	EvaluationJobCancelledNotRetried = createMessage(
+		constants.HTTPCodeConflict,
+		"The evaluation job {{.ResourceId}} was cancelled and can not be retried.",
+	)
+
+	// EvaluationJobRenderFailed The resources of the evaluation job could not be rendered: {{.Error}}.
+	EvaluationJobRenderFailed = createMessage(
+		constants.HTTPCodeUnprocessableEntity,
+		"The resources of the evaluation job could not be rendered: {{.Error}}.",
+	)
+
+	// EvaluationJobConfigInvalid The evaluation job configuration is invalid: {{.Errors}}.
+	EvaluationJobConfigInvalid = createMessage(
+		constants.HTTPCodeUnprocessableEntity,
+		"The evaluation job configuration is invalid: {{.Errors}}.",
+	)
+
 	// QueryParameterRequired The query parameter '{{.ParameterName}}' is required.
 	QueryParameterRequired = createMessage(
 		constants.HTTPCodeBadRequest,
@@ -35,6 +77,11 @@ var (
 		constants.HTTPCodeBadRequest,
 		"The query parameter '{{.ParameterName}}' is not a valid {{.Type}}: '{{.Value}}'.",
 	)
+	// QueryParametersExclusive The query parameters '{{.ParameterName}}' and '{{.OtherParameterName}}' can not be used together.
+	QueryParametersExclusive = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The query parameters '{{.ParameterName}}' and '{{.OtherParameterName}}' can not be used together.",
+	)
 
 	// InvalidJSONRequest The request JSON is invalid: '{{.Error}}'. Please check the request and try again.
 	InvalidJSONRequest = createMessage(
@@ -101,6 +148,18 @@ var (
 		"The HTTP method {{.Method}} is not allowed for the API {{.Api}}.",
 	)
 
+	// RequestBodyTooLarge The request body exceeds the limit of {{.Limit}} bytes.
+	RequestBodyTooLarge = createMessage(
+		constants.HTTPCodeRequestTooLarge,
+		"The request body exceeds the limit of {{.Limit}} bytes.",
+	)
+
+	// ServiceShuttingDown The service is shutting down and does not accept new evaluation jobs.
+	ServiceShuttingDown = createMessage(
+		constants.HTTPCodeServiceUnavailable,
+		"The service is shutting down and does not accept new evaluation jobs.",
+	)
+
 	// NotImplemented The API {{.Api}} is not yet implemented.
 	NotImplemented = createMessage(
 		constants.HTTPCodeNotImplemented,
@@ -0,0 +1,254 @@
+package docker
+
+// Contains the configuration logic that prepares the containers of the benchmarks
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	defaultCPULimit    = "1"
+	defaultMemoryLimit = "2Gi"
+	serviceURLEnv      = "SERVICE_URL"
+	configDirEnv       = "EVALHUB_DOCKER_CONFIG_DIR"
+	jobSpecFileName    = "job.json"
+	// jobSpecMountDir matches the directory the Kubernetes runtime mounts the ConfigMap in,
+	// so adapters find the job spec at the same path in both runtimes.
+	jobSpecMountDir     = "/meta"
+	containerPrefix     = "eval-job-"
+	envJobIDName        = "JOB_ID"
+	labelAppKey         = "app"
+	labelComponentKey   = "component"
+	labelJobIDKey       = "job_id"
+	labelProviderIDKey  = "provider_id"
+	labelBenchmarkIDKey = "benchmark_id"
+	labelAppValue       = "evalhub"
+	labelComponentValue = "evaluation-job"
+)
+
+var containerNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+type containerSettings struct {
+	jobID       string
+	providerID  string
+	benchmarkID string
+	name        string
+	configDir   string
+	jobSpecJSON string
+	config      *container.CreateRequest
+}
+
+// jobSpec is the job.json read by the adapters, it has the same layout as the one
+// the Kubernetes runtime puts in the ConfigMap.
+type jobSpec struct {
+	JobID           string              `json:"job_id"`
+	BenchmarkID     string              `json:"benchmark_id"`
+	Model           api.ModelRef        `json:"model"`
+	NumExamples     *int                `json:"num_examples,omitempty"`
+	BenchmarkConfig map[string]any      `json:"benchmark_config"`
+	ExperimentName  string              `json:"experiment_name,omitempty"`
+	Tags            []api.ExperimentTag `json:"tags,omitempty"`
+	TimeoutSeconds  *int                `json:"timeout_seconds,omitempty"`
+	RetryAttempts   *int                `json:"retry_attempts,omitempty"`
+	CallbackURL     *string             `json:"callback_url"`
+}
+
+// validateDockerRuntime checks the provider settings that do not depend on the evaluation job.
+func validateDockerRuntime(runtime *api.DockerRuntime) error {
+	var errs []string
+	if runtime.Image == "" {
+		errs = append(errs, "runtime adapter image is required")
+	}
+	if _, err := parseNanoCPUs(defaultIfEmpty(runtime.CPULimit, defaultCPULimit)); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if _, err := parseMemoryBytes(defaultIfEmpty(runtime.MemoryLimit, defaultMemoryLimit)); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func buildContainerSettings(evaluation *api.EvaluationJobResource, provider *api.ProviderResource, benchmarkID string, baseDir string) (*containerSettings, error) {
+	runtime := provider.Runtime
+	if runtime == nil || runtime.Docker == nil {
+		return nil, fmt.Errorf("provider %q missing docker runtime configuration", provider.ProviderID)
+	}
+	if err := validateDockerRuntime(runtime.Docker); err != nil {
+		return nil, err
+	}
+	if evaluation.Model.URL == "" || evaluation.Model.Name == "" {
+		return nil, fmt.Errorf("model url and name are required")
+	}
+	benchmarkConfig, err := findBenchmarkConfig(evaluation, benchmarkID)
+	if err != nil {
+		return nil, err
+	}
+	benchmarkParams := copyParams(benchmarkConfig.Parameters)
+	numExamples := numExamplesFromParameters(benchmarkParams)
+	delete(benchmarkParams, "num_examples")
+	if len(benchmarkParams) == 0 {
+		return nil, fmt.Errorf("benchmark_config is required")
+	}
+	nanoCPUs, _ := parseNanoCPUs(defaultIfEmpty(runtime.Docker.CPULimit, defaultCPULimit))
+	memory, _ := parseMemoryBytes(defaultIfEmpty(runtime.Docker.MemoryLimit, defaultMemoryLimit))
+
+	spec := jobSpec{
+		JobID:           evaluation.Resource.ID,
+		BenchmarkID:     benchmarkID,
+		Model:           evaluation.Model,
+		NumExamples:     numExamples,
+		BenchmarkConfig: benchmarkParams,
+		TimeoutSeconds:  timeoutSecondsFromMinutes(evaluation.TimeoutMinutes),
+		RetryAttempts:   evaluation.RetryAttempts,
+	}
+	if serviceURL := strings.TrimSpace(os.Getenv(serviceURLEnv)); serviceURL != "" {
+		spec.CallbackURL = &serviceURL
+	}
+	if evaluation.Experiment != nil {
+		spec.ExperimentName = evaluation.Experiment.Name
+		spec.Tags = evaluation.Experiment.Tags
+	}
+	specJSON, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal job spec: %w", err)
+	}
+
+	name := containerName(evaluation.Resource.ID, benchmarkID)
+	configDir := filepath.Join(baseDir, name)
+	return &containerSettings{
+		jobID:       evaluation.Resource.ID,
+		providerID:  provider.ProviderID,
+		benchmarkID: benchmarkID,
+		name:        name,
+		configDir:   configDir,
+		jobSpecJSON: string(specJSON),
+		config: &container.CreateRequest{
+			Config: &container.Config{
+				Image:      runtime.Docker.Image,
+				Entrypoint: runtime.Docker.Entrypoint,
+				Env:        buildEnv(evaluation.Resource.ID, runtime.Docker.Env),
+				Labels:     containerLabels(evaluation.Resource.ID, provider.ProviderID, benchmarkID),
+			},
+			HostConfig: &container.HostConfig{
+				Binds: []string{configDir + ":" + jobSpecMountDir + ":ro"},
+				Resources: container.Resources{
+					NanoCPUs: nanoCPUs,
+					Memory:   memory,
+				},
+			},
+		},
+	}, nil
+}
+
+// resolveConfigDir returns the host directory the job spec files are written to.
+func resolveConfigDir() string {
+	if dir := strings.TrimSpace(os.Getenv(configDirEnv)); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "eval-hub", "docker")
+}
+
+func containerName(jobID, benchmarkID string) string {
+	return containerPrefix + containerNameSanitizer.ReplaceAllString(jobID+"-"+benchmarkID, "-")
+}
+
+func containerLabels(jobID, providerID, benchmarkID string) map[string]string {
+	return map[string]string{
+		labelAppKey:         labelAppValue,
+		labelComponentKey:   labelComponentValue,
+		labelJobIDKey:       jobID,
+		labelProviderIDKey:  providerID,
+		labelBenchmarkIDKey: benchmarkID,
+	}
+}
+
+func buildEnv(jobID string, defaultEnv []api.EnvVar) []string {
+	env := []string{envJobIDName + "=" + jobID}
+	seen := map[string]bool{envJobIDName: true}
+	for _, item := range defaultEnv {
+		if item.Name == "" || seen[item.Name] {
+			continue
+		}
+		seen[item.Name] = true
+		env = append(env, item.Name+"="+item.Value)
+	}
+	return env
+}
+
+func parseNanoCPUs(value string) (int64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cpu limit %q: %w", value, err)
+	}
+	return quantity.MilliValue() * 1_000_000, nil
+}
+
+func parseMemoryBytes(value string) (int64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit %q: %w", value, err)
+	}
+	return quantity.Value(), nil
+}
+
+func defaultIfEmpty(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func findBenchmarkConfig(evaluation *api.EvaluationJobResource, benchmarkID string) (*api.BenchmarkConfig, error) {
+	for i := range evaluation.Benchmarks {
+		benchmark := &evaluation.Benchmarks[i]
+		if benchmark.ID == benchmarkID {
+			return benchmark, nil
+		}
+	}
+	return nil, fmt.Errorf("benchmark config not found for %q", benchmarkID)
+}
+
+func timeoutSecondsFromMinutes(minutes *int) *int {
+	if minutes == nil {
+		return nil
+	}
+	seconds := *minutes * 60
+	return &seconds
+}
+
+func copyParams(source map[string]any) map[string]any {
+	clone := make(map[string]any, len(source))
+	for key, value := range source {
+		clone[key] = value
+	}
+	return clone
+}
+
+func numExamplesFromParameters(parameters map[string]any) *int {
+	switch typed := parameters["num_examples"].(type) {
+	case int:
+		return &typed
+	case int32:
+		converted := int(typed)
+		return &converted
+	case int64:
+		converted := int(typed)
+		return &converted
+	case float64:
+		converted := int(typed)
+		return &converted
+	default:
+		return nil
+	}
+}
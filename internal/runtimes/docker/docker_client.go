@@ -0,0 +1,106 @@
+package docker
+
+// Client for the Docker daemon, built on the Docker SDK. Only the container operations used
+// by the runtime are exposed.
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Client talks to the Docker daemon.
+type Client struct {
+	api *client.Client
+}
+
+// NewClientFromEnv creates a client for the daemon configured by the DOCKER_HOST,
+// DOCKER_API_VERSION, DOCKER_CERT_PATH and DOCKER_TLS_VERIFY variables, defaulting to the
+// local socket. The API version is negotiated with the daemon.
+func NewClientFromEnv() (*Client, error) {
+	return newClient(client.FromEnv, client.WithAPIVersionNegotiation())
+}
+
+func newClient(opts ...client.Opt) (*Client, error) {
+	api, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create docker client: %w", err)
+	}
+	return &Client{api: api}, nil
+}
+
+// CreateContainer creates a container with the given name and returns its ID.
+func (c *Client) CreateContainer(ctx context.Context, name string, config *container.CreateRequest) (string, error) {
+	created, err := c.api.ContainerCreate(ctx, config.Config, config.HostConfig, config.NetworkingConfig, nil, name)
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// StartContainer starts a created container.
+func (c *Client) StartContainer(ctx context.Context, id string) error {
+	return c.api.ContainerStart(ctx, id, container.StartOptions{})
+}
+
+// RemoveContainer removes a container, killing it first when force is set.
+func (c *Client) RemoveContainer(ctx context.Context, id string, force bool) error {
+	return c.api.ContainerRemove(ctx, id, container.RemoveOptions{Force: force})
+}
+
+// ListContainers returns all containers, running or not, that have every given label.
+func (c *Client) ListContainers(ctx context.Context, labels map[string]string) ([]container.Summary, error) {
+	labelFilters := filters.NewArgs()
+	for key, value := range labels {
+		labelFilters.Add("label", key+"="+value)
+	}
+	return c.api.ContainerList(ctx, container.ListOptions{All: true, Filters: labelFilters})
+}
+
+// errContainerNotFound is returned when the daemon has no container with the given name or ID.
+var errContainerNotFound = cerrdefs.ErrNotFound
+
+// ContainerLogs streams the stdout and stderr of a container. Follow keeps the stream open
+// until the container stops, tail limits the logs to the last lines when not nil.
+func (c *Client) ContainerLogs(ctx context.Context, id string, follow bool, tail *int64) (io.ReadCloser, error) {
+	options := container.LogsOptions{ShowStdout: true, ShowStderr: true, Follow: follow}
+	if tail != nil {
+		options.Tail = strconv.FormatInt(*tail, 10)
+	}
+	logs, err := c.api.ContainerLogs(ctx, id, options)
+	if err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return nil, fmt.Errorf("container %s: %w", id, errContainerNotFound)
+		}
+		return nil, err
+	}
+	return demultiplexLogs(logs), nil
+}
+
+// demultiplexLogs strips the frame headers the daemon adds to the logs of containers that
+// run without a TTY, stdout and stderr frames are passed through in order.
+func demultiplexLogs(logs io.ReadCloser) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(writer, writer, logs)
+		_ = writer.CloseWithError(err)
+	}()
+	return &logReader{PipeReader: reader, logs: logs}
+}
+
+// logReader closes the stream of the daemon together with the demultiplexed one.
+type logReader struct {
+	*io.PipeReader
+	logs io.ReadCloser
+}
+
+func (l *logReader) Close() error {
+	_ = l.PipeReader.Close()
+	return l.logs.Close()
+}
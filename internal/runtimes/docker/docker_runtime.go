@@ -0,0 +1,338 @@
+package docker
+
+// Runtime entrypoints for running benchmarks as local Docker containers.
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type DockerRuntime struct {
+	logger    *slog.Logger
+	client    *Client
+	providers map[string]api.ProviderResource
+	configDir string
+	ctx       context.Context
+	// submissions is shared by the copies of the runtime
+	submissions *submissionTracker
+}
+
+// NewDockerRuntime creates a Docker runtime talking to the daemon configured by the Docker
+// environment variables.
+func NewDockerRuntime(logger *slog.Logger, providerConfigs map[string]api.ProviderResource) (abstractions.Runtime, error) {
+	if err := validateProviderConfigs(providerConfigs); err != nil {
+		return nil, err
+	}
+	client, err := NewClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &DockerRuntime{
+		logger:      logger,
+		client:      client,
+		providers:   providerConfigs,
+		configDir:   resolveConfigDir(),
+		ctx:         context.Background(),
+		submissions: newSubmissionTracker(),
+	}, nil
+}
+
+func (r *DockerRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
+	return &DockerRuntime{
+		logger:      logger,
+		client:      r.client,
+		providers:   r.providers,
+		configDir:   r.configDir,
+		ctx:         r.ctx,
+		submissions: r.submissions,
+	}
+}
+
+func (r *DockerRuntime) WithContext(ctx context.Context) abstractions.Runtime {
+	return &DockerRuntime{
+		logger:      r.logger,
+		client:      r.client,
+		providers:   r.providers,
+		configDir:   r.configDir,
+		ctx:         ctx,
+		submissions: r.submissions,
+	}
+}
+
+// validateProviderConfigs checks the Docker settings of all providers at startup.
+func validateProviderConfigs(providerConfigs map[string]api.ProviderResource) error {
+	var errs []error
+	for id, provider := range providerConfigs {
+		if provider.Runtime == nil || provider.Runtime.Docker == nil {
+			continue
+		}
+		if err := validateDockerRuntime(provider.Runtime.Docker); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateBenchmarks builds the settings of every benchmark container before anything is
+// created, so all configuration errors of the job are reported together.
+func (r *DockerRuntime) validateBenchmarks(evaluation *api.EvaluationJobResource) ([]*containerSettings, error) {
+	var errs []error
+	settings := make([]*containerSettings, 0, len(evaluation.Benchmarks))
+	for i := range evaluation.Benchmarks {
+		benchmark := &evaluation.Benchmarks[i]
+		provider := r.providers[benchmark.ProviderID]
+		containerSettings, err := buildContainerSettings(evaluation, &provider, benchmark.ID, r.configDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("benchmark %s: %w", benchmark.ID, err))
+			continue
+		}
+		settings = append(settings, containerSettings)
+	}
+	return settings, errors.Join(errs...)
+}
+
+func (r *DockerRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, storage *abstractions.Storage) error {
+	settings, err := r.validateBenchmarks(evaluation)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", evaluation.Resource.ID, err)
+	}
+
+	// the containers outlive the request that started them
+	ctx, done, err := r.submissions.start(context.WithoutCancel(r.ctx))
+	if err != nil {
+		return fmt.Errorf("job %s: %w", evaluation.Resource.ID, err)
+	}
+	if storage != nil && *storage != nil {
+		detached := (*storage).WithContext(ctx)
+		storage = &detached
+	}
+	go func() {
+		defer done()
+		for i, containerSettings := range settings {
+			if ctx.Err() != nil {
+				r.logger.Warn(
+					"benchmark processing canceled",
+					"job_id", evaluation.Resource.ID,
+					"benchmark_id", containerSettings.benchmarkID,
+				)
+				return
+			}
+			if err := r.runBenchmarkContainer(ctx, containerSettings); err != nil {
+				r.logger.Error(
+					"docker container creation failed",
+					"error", err,
+					"job_id", evaluation.Resource.ID,
+					"benchmark_id", containerSettings.benchmarkID,
+				)
+				if storage != nil && *storage != nil {
+					runStatus := buildBenchmarkFailureStatus(&evaluation.Benchmarks[i], err)
+					if updateErr := (*storage).UpdateEvaluationJob(evaluation.Resource.ID, runStatus); updateErr != nil {
+						r.logger.Error(
+							"failed to update benchmark status",
+							"error", updateErr,
+							"job_id", evaluation.Resource.ID,
+							"benchmark_id", containerSettings.benchmarkID,
+						)
+					}
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown waits for the containers of the jobs in progress to be created until the context
+// is done. The containers keep running.
+func (r *DockerRuntime) Shutdown(ctx context.Context) error {
+	return r.submissions.shutdown(ctx)
+}
+
+// renderedContainer is the manifest of a benchmark container in a dry run, the container
+// configuration together with the job spec that would be mounted in it.
+type renderedContainer struct {
+	Config  *container.CreateRequest `json:"config"`
+	JobSpec json.RawMessage          `json:"job_spec"`
+}
+
+// RenderEvaluationJob returns the container of every benchmark as RunEvaluationJob would
+// create it, after the same validation.
+func (r *DockerRuntime) RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error) {
+	settings, err := r.validateBenchmarks(evaluation)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", evaluation.Resource.ID, err)
+	}
+	rendered := make([]api.RenderedResource, 0, len(settings))
+	for _, containerSettings := range settings {
+		manifest, err := json.Marshal(&renderedContainer{
+			Config:  containerSettings.config,
+			JobSpec: json.RawMessage(containerSettings.jobSpecJSON),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, containerSettings.benchmarkID, err)
+		}
+		rendered = append(rendered, api.RenderedResource{
+			Kind:        "Container",
+			Name:        containerSettings.name,
+			ProviderID:  containerSettings.providerID,
+			BenchmarkID: containerSettings.benchmarkID,
+			Manifest:    manifest,
+		})
+	}
+	return rendered, nil
+}
+
+// runBenchmarkContainer writes the job spec to the host directory that is bind mounted
+// in the container, then creates and starts the container. The container of an earlier
+// run of the benchmark, which a retry leaves behind, is removed first as it has the same name.
+func (r *DockerRuntime) runBenchmarkContainer(ctx context.Context, settings *containerSettings) error {
+	stale, err := r.client.ListContainers(ctx, map[string]string{labelJobIDKey: settings.jobID, labelBenchmarkIDKey: settings.benchmarkID})
+	if err != nil {
+		return fmt.Errorf("job %s benchmark %s: list containers: %w", settings.jobID, settings.benchmarkID, err)
+	}
+	for _, container := range stale {
+		if err := r.client.RemoveContainer(ctx, container.ID, true); err != nil {
+			return fmt.Errorf("job %s benchmark %s: remove container %s: %w", settings.jobID, settings.benchmarkID, container.ID, err)
+		}
+	}
+	if err := os.MkdirAll(settings.configDir, 0o755); err != nil {
+		return fmt.Errorf("job %s benchmark %s: create config dir: %w", settings.jobID, settings.benchmarkID, err)
+	}
+	specPath := filepath.Join(settings.configDir, jobSpecFileName)
+	if err := os.WriteFile(specPath, []byte(settings.jobSpecJSON), 0o644); err != nil {
+		return fmt.Errorf("job %s benchmark %s: write job spec: %w", settings.jobID, settings.benchmarkID, err)
+	}
+
+	id, err := r.client.CreateContainer(ctx, settings.name, settings.config)
+	if err != nil {
+		return fmt.Errorf("job %s benchmark %s: %w", settings.jobID, settings.benchmarkID, err)
+	}
+	if err := r.client.StartContainer(ctx, id); err != nil {
+		if removeErr := r.client.RemoveContainer(ctx, id, true); removeErr != nil {
+			r.logger.Error("failed to remove container after start error", "container_id", id, "error", removeErr)
+		}
+		return fmt.Errorf("job %s benchmark %s: %w", settings.jobID, settings.benchmarkID, err)
+	}
+	r.logger.Info(
+		"docker container started",
+		"job_id", settings.jobID,
+		"benchmark_id", settings.benchmarkID,
+		"container_id", id,
+		"container_name", settings.name,
+	)
+	return nil
+}
+
+// CancelEvaluationJob force removes the containers labelled with the job ID and the
+// job spec files written for them.
+func (r *DockerRuntime) CancelEvaluationJob(jobID string) error {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	containers, err := r.client.ListContainers(ctx, map[string]string{labelJobIDKey: jobID})
+	if err != nil {
+		return fmt.Errorf("job %s: list containers: %w", jobID, err)
+	}
+	var errs []error
+	for _, container := range containers {
+		if err := r.client.RemoveContainer(ctx, container.ID, true); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: remove container %s: %w", jobID, container.ID, err))
+			continue
+		}
+		r.logger.Info("docker container removed", "job_id", jobID, "container_id", container.ID)
+		configDir := filepath.Join(r.configDir, containerName(jobID, container.Labels[labelBenchmarkIDKey]))
+		if err := os.RemoveAll(configDir); err != nil {
+			r.logger.Warn("failed to remove job spec directory", "job_id", jobID, "path", configDir, "error", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CancelBenchmark force removes the container of the benchmark and its job spec file.
+func (r *DockerRuntime) CancelBenchmark(jobID string, benchmarkID string) error {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	containers, err := r.client.ListContainers(ctx, map[string]string{labelJobIDKey: jobID, labelBenchmarkIDKey: benchmarkID})
+	if err != nil {
+		return fmt.Errorf("job %s benchmark %s: list containers: %w", jobID, benchmarkID, err)
+	}
+	var errs []error
+	for _, container := range containers {
+		if err := r.client.RemoveContainer(ctx, container.ID, true); err != nil {
+			errs = append(errs, fmt.Errorf("job %s benchmark %s: remove container %s: %w", jobID, benchmarkID, container.ID, err))
+			continue
+		}
+		r.logger.Info("docker container removed", "job_id", jobID, "benchmark_id", benchmarkID, "container_id", container.ID)
+	}
+	if len(errs) == 0 {
+		configDir := filepath.Join(r.configDir, containerName(jobID, benchmarkID))
+		if err := os.RemoveAll(configDir); err != nil {
+			r.logger.Warn("failed to remove job spec directory", "job_id", jobID, "path", configDir, "error", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CountActiveJobs counts the evaluation containers that are created or running.
+func (r *DockerRuntime) CountActiveJobs() (int, error) {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	containers, err := r.client.ListContainers(ctx, map[string]string{
+		labelAppKey:       labelAppValue,
+		labelComponentKey: labelComponentValue,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list containers: %w", err)
+	}
+	count := 0
+	for _, container := range containers {
+		switch container.State {
+		case "created", "running", "restarting":
+			count++
+		}
+	}
+	return count, nil
+}
+
+// StreamBenchmarkLogs streams the logs of the container running the benchmark.
+func (r *DockerRuntime) StreamBenchmarkLogs(jobID string, benchmarkID string, options abstractions.LogOptions) (io.ReadCloser, error) {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logs, err := r.client.ContainerLogs(ctx, containerName(jobID, benchmarkID), options.Follow, options.TailLines)
+	if errors.Is(err, errContainerNotFound) {
+		return nil, fmt.Errorf("job %s benchmark %s: %w", jobID, benchmarkID, abstractions.ErrBenchmarkNotFound)
+	}
+	return logs, err
+}
+
+func buildBenchmarkFailureStatus(benchmark *api.BenchmarkConfig, runErr error) *api.StatusEvent {
+	return &api.StatusEvent{
+		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:   benchmark.ProviderID,
+			ID:           benchmark.ID,
+			Status:       api.StateFailed,
+			ErrorMessage: &api.MessageInfo{Message: runErr.Error(), MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED},
+		},
+	}
+}
+
+func (r *DockerRuntime) Name() string {
+	return "docker"
+}
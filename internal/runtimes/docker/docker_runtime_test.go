@@ -0,0 +1,398 @@
+package docker
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// apiVersionPrefix is the version the SDK puts in front of the paths of the Engine API.
+var apiVersionPrefix = regexp.MustCompile(`^/v[0-9.]+`)
+
+type fakeDaemon struct {
+	mu       sync.Mutex
+	created  []container.CreateRequest
+	names    []string
+	started  []string
+	startErr bool
+	filters  []string
+	removed  []string
+	logQuery string
+}
+
+func (d *fakeDaemon) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		r.URL.Path = apiVersionPrefix.ReplaceAllString(r.URL.Path, "")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/containers/create":
+			var config container.CreateRequest
+			if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+				t.Errorf("failed to decode create body: %v", err)
+			}
+			d.created = append(d.created, config)
+			d.names = append(d.names, r.URL.Query().Get("name"))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"Id":"container-1"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/start"):
+			if d.startErr {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"message":"boom"}`))
+				return
+			}
+			d.started = append(d.started, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/containers/"), "/start"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/containers/json":
+			d.filters = append(d.filters, r.URL.Query().Get("filters"))
+			_, _ = w.Write([]byte(`[{"Id":"container-1","State":"running","Labels":{"job_id":"job-1","benchmark_id":"bench-1"}},{"Id":"container-2","State":"exited","Labels":{"job_id":"job-1","benchmark_id":"bench-2"}}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/containers/eval-job-job-1-bench-1/logs":
+			d.logQuery = r.URL.RawQuery
+			_, _ = w.Write(logFrame(1, "stdout line\n"))
+			_, _ = w.Write(logFrame(2, "stderr line\n"))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/logs"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No such container"}`))
+		case r.Method == http.MethodDelete:
+			d.removed = append(d.removed, strings.TrimPrefix(r.URL.Path, "/containers/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+// logFrame encodes a log line the way the daemon does for containers without a TTY.
+func logFrame(stream byte, line string) []byte {
+	frame := make([]byte, 8, 8+len(line))
+	frame[0] = stream
+	binary.BigEndian.PutUint32(frame[4:], uint32(len(line)))
+	return append(frame, line...)
+}
+
+func (d *fakeDaemon) startedCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.started)
+}
+
+func newTestRuntime(t *testing.T, daemon *fakeDaemon, providers map[string]api.ProviderResource) *DockerRuntime {
+	server := httptest.NewServer(daemon.handler(t))
+	t.Cleanup(server.Close)
+	dockerClient, err := newClient(client.WithHost(server.URL), client.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to create docker client: %v", err)
+	}
+	return &DockerRuntime{
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		client:      dockerClient,
+		providers:   providers,
+		configDir:   t.TempDir(),
+		ctx:         context.Background(),
+		submissions: newSubmissionTracker(),
+	}
+}
+
+func TestRunEvaluationJobStartsContainerPerBenchmark(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	evaluation := sampleEvaluation("provider-1")
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("RunEvaluationJob returned error: %v", err)
+	}
+	waitFor(t, func() bool { return daemon.startedCount() == 1 })
+
+	daemon.mu.Lock()
+	defer daemon.mu.Unlock()
+	config := daemon.created[0]
+	if config.Image != "adapter:latest" {
+		t.Fatalf("expected image adapter:latest, got %q", config.Image)
+	}
+	if config.Labels[labelJobIDKey] != "job-1" || config.Labels[labelBenchmarkIDKey] != "bench-1" {
+		t.Fatalf("expected job and benchmark labels, got %v", config.Labels)
+	}
+	if config.HostConfig.NanoCPUs != 1_000_000_000 {
+		t.Fatalf("expected 1 cpu, got %d nano cpus", config.HostConfig.NanoCPUs)
+	}
+	if len(config.HostConfig.Binds) != 1 || !strings.HasSuffix(config.HostConfig.Binds[0], ":"+jobSpecMountDir+":ro") {
+		t.Fatalf("expected job spec bind mount, got %v", config.HostConfig.Binds)
+	}
+	configDir := strings.TrimSuffix(config.HostConfig.Binds[0], ":"+jobSpecMountDir+":ro")
+	content, err := os.ReadFile(filepath.Join(configDir, jobSpecFileName))
+	if err != nil {
+		t.Fatalf("expected job spec file to be written: %v", err)
+	}
+	var spec jobSpec
+	if err := json.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("failed to decode job spec: %v", err)
+	}
+	if spec.JobID != "job-1" || spec.BenchmarkID != "bench-1" {
+		t.Fatalf("unexpected job spec: %+v", spec)
+	}
+	if daemon.names[0] != "eval-job-job-1-bench-1" {
+		t.Fatalf("unexpected container name %q", daemon.names[0])
+	}
+}
+
+func TestShutdownWaitsForSubmissionsAndRefusesJobs(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	evaluation := sampleEvaluation("provider-1")
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("RunEvaluationJob returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := runtime.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if daemon.startedCount() != 1 {
+		t.Fatalf("expected the container to be started before the shutdown returned, got %d", daemon.startedCount())
+	}
+	if err := runtime.RunEvaluationJob(evaluation, nil); !errors.Is(err, errShuttingDown) {
+		t.Fatalf("expected the job to be refused, got %v", err)
+	}
+}
+
+func TestRunEvaluationJobAggregatesValidationErrors(t *testing.T) {
+	daemon := &fakeDaemon{}
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.Docker.Image = ""
+	runtime := newTestRuntime(t, daemon, providers)
+	evaluation := sampleEvaluation("provider-1")
+	evaluation.Benchmarks = append(evaluation.Benchmarks, api.BenchmarkConfig{
+		Ref:        api.Ref{ID: "bench-2"},
+		ProviderID: "provider-1",
+		Parameters: map[string]any{"foo": "baz"},
+	})
+
+	err := runtime.RunEvaluationJob(evaluation, nil)
+	if err == nil {
+		t.Fatalf("expected error for missing image")
+	}
+	for _, id := range []string{"bench-1", "bench-2"} {
+		if !strings.Contains(err.Error(), "benchmark "+id+": runtime adapter image is required") {
+			t.Fatalf("expected image error for %s, got %v", id, err)
+		}
+	}
+	if len(daemon.created) != 0 {
+		t.Fatalf("expected no containers to be created")
+	}
+}
+
+func TestRenderEvaluationJobReturnsContainerWithoutCreating(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	evaluation := sampleEvaluation("provider-1")
+
+	resources, err := runtime.RenderEvaluationJob(evaluation)
+	if err != nil {
+		t.Fatalf("RenderEvaluationJob returned error: %v", err)
+	}
+	if len(daemon.created) != 0 {
+		t.Fatalf("expected no containers to be created")
+	}
+	if len(resources) != 1 || resources[0].Kind != "Container" || resources[0].Name != "eval-job-job-1-bench-1" {
+		t.Fatalf("unexpected rendered resources %+v", resources)
+	}
+	var manifest renderedContainer
+	if err := json.Unmarshal(resources[0].Manifest, &manifest); err != nil {
+		t.Fatalf("failed to decode the manifest: %v", err)
+	}
+	var spec jobSpec
+	if err := json.Unmarshal(manifest.JobSpec, &spec); err != nil {
+		t.Fatalf("failed to decode the job spec: %v", err)
+	}
+	if manifest.Config.Image != "adapter:latest" || spec.BenchmarkID != "bench-1" {
+		t.Fatalf("unexpected manifest %+v with job spec %+v", manifest.Config, spec)
+	}
+}
+
+func TestRunBenchmarkContainerReportsStartFailure(t *testing.T) {
+	daemon := &fakeDaemon{startErr: true}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	evaluation := sampleEvaluation("provider-1")
+	settings, err := runtime.validateBenchmarks(evaluation)
+	if err != nil {
+		t.Fatalf("validateBenchmarks returned error: %v", err)
+	}
+
+	err = runtime.runBenchmarkContainer(context.Background(), settings[0])
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected start error with daemon message, got %v", err)
+	}
+}
+
+func TestCancelEvaluationJobRemovesLabelledContainers(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	configDir := filepath.Join(runtime.configDir, containerName("job-1", "bench-1"))
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	if err := runtime.CancelEvaluationJob("job-1"); err != nil {
+		t.Fatalf("CancelEvaluationJob returned error: %v", err)
+	}
+	if len(daemon.filters) != 1 || !strings.Contains(daemon.filters[0], `"job_id=job-1"`) {
+		t.Fatalf("expected containers to be filtered by job label, got %v", daemon.filters)
+	}
+	if len(daemon.removed) != 2 || daemon.removed[0] != "container-1" {
+		t.Fatalf("expected the job containers to be removed, got %v", daemon.removed)
+	}
+	if _, err := os.Stat(configDir); !os.IsNotExist(err) {
+		t.Fatalf("expected config dir to be removed, got %v", err)
+	}
+}
+
+func TestCancelBenchmarkRemovesBenchmarkContainer(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	configDir := filepath.Join(runtime.configDir, containerName("job-1", "bench-1"))
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	if err := runtime.CancelBenchmark("job-1", "bench-1"); err != nil {
+		t.Fatalf("CancelBenchmark returned error: %v", err)
+	}
+	if len(daemon.filters) != 1 || !strings.Contains(daemon.filters[0], `"job_id=job-1"`) || !strings.Contains(daemon.filters[0], `"benchmark_id=bench-1"`) {
+		t.Fatalf("expected containers to be filtered by job and benchmark labels, got %v", daemon.filters)
+	}
+	if _, err := os.Stat(configDir); !os.IsNotExist(err) {
+		t.Fatalf("expected config dir to be removed, got %v", err)
+	}
+}
+
+func TestCountActiveJobsCountsRunningContainers(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+
+	count, err := runtime.CountActiveJobs()
+	if err != nil {
+		t.Fatalf("CountActiveJobs returned error: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 active container, got %d", count)
+	}
+	if len(daemon.filters) != 1 || !strings.Contains(daemon.filters[0], `"app=evalhub"`) {
+		t.Fatalf("expected containers to be filtered by app label, got %v", daemon.filters)
+	}
+}
+
+func TestStreamBenchmarkLogsDemultiplexesContainerLogs(t *testing.T) {
+	daemon := &fakeDaemon{}
+	runtime := newTestRuntime(t, daemon, sampleProviders("provider-1"))
+	tail := int64(20)
+
+	logs, err := runtime.StreamBenchmarkLogs("job-1", "bench-1", abstractions.LogOptions{Follow: true, TailLines: &tail})
+	if err != nil {
+		t.Fatalf("StreamBenchmarkLogs returned error: %v", err)
+	}
+	defer logs.Close()
+	content, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatalf("failed to read logs: %v", err)
+	}
+	if string(content) != "stdout line\nstderr line\n" {
+		t.Fatalf("unexpected logs %q", content)
+	}
+	if !strings.Contains(daemon.logQuery, "follow=1") || !strings.Contains(daemon.logQuery, "tail=20") {
+		t.Fatalf("expected follow and tail to be passed, got %q", daemon.logQuery)
+	}
+
+	_, err = runtime.StreamBenchmarkLogs("job-1", "bench-2", abstractions.LogOptions{})
+	if !errors.Is(err, abstractions.ErrBenchmarkNotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestValidateProviderConfigsRejectsInvalidLimits(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.Docker.MemoryLimit = "lots"
+
+	if err := validateProviderConfigs(providers); err == nil {
+		t.Fatalf("expected error for invalid memory limit")
+	}
+}
+
+func TestNewClientFromEnvUsesDockerHost(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "tcp://docker.example.com:2375")
+	dockerClient, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv returned error: %v", err)
+	}
+	if host := dockerClient.api.DaemonHost(); host != "tcp://docker.example.com:2375" {
+		t.Fatalf("expected the daemon in DOCKER_HOST, got %q", host)
+	}
+
+	t.Setenv("DOCKER_HOST", "not a host")
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Fatalf("expected error for invalid docker host")
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("condition not met before timeout")
+}
+
+func sampleEvaluation(providerID string) *api.EvaluationJobResource {
+	return &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{
+			Resource: api.Resource{ID: "job-1"},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{
+				URL:  "http://model",
+				Name: "model",
+			},
+			Benchmarks: []api.BenchmarkConfig{
+				{
+					Ref:        api.Ref{ID: "bench-1"},
+					ProviderID: providerID,
+					Parameters: map[string]any{"foo": "bar"},
+				},
+			},
+		},
+	}
+}
+
+func sampleProviders(providerID string) map[string]api.ProviderResource {
+	return map[string]api.ProviderResource{
+		providerID: {
+			ProviderID: providerID,
+			Runtime: &api.Runtime{
+				Docker: &api.DockerRuntime{
+					Image: "adapter:latest",
+				},
+			},
+		},
+	}
+}
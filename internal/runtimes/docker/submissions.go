@@ -0,0 +1,77 @@
+package docker
+
+// Tracking of the container submissions that are still in progress.
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errShuttingDown is returned for a job that is run after the runtime started to shut down.
+var errShuttingDown = errors.New("the docker runtime is shutting down")
+
+// submissionTracker counts the jobs whose containers are still being created so that a
+// shutdown can wait for them. It is shared by the copies of the runtime, a nil tracker does
+// not track anything.
+type submissionTracker struct {
+	mu      sync.Mutex
+	closing bool
+	running sync.WaitGroup
+	// submitting is done when the submissions must stop
+	submitting     context.Context
+	stopSubmitting context.CancelFunc
+}
+
+func newSubmissionTracker() *submissionTracker {
+	submitting, stopSubmitting := context.WithCancel(context.Background())
+	return &submissionTracker{submitting: submitting, stopSubmitting: stopSubmitting}
+}
+
+// start returns the context of the submissions of a job and the function to call once they
+// are done. It fails once the runtime is shutting down.
+func (t *submissionTracker) start(parent context.Context) (context.Context, func(), error) {
+	ctx, cancel := context.WithCancel(parent)
+	if t == nil {
+		return ctx, cancel, nil
+	}
+	t.mu.Lock()
+	if t.closing {
+		t.mu.Unlock()
+		cancel()
+		return nil, nil, errShuttingDown
+	}
+	t.running.Add(1)
+	t.mu.Unlock()
+	stop := context.AfterFunc(t.submitting, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+		t.running.Done()
+	}, nil
+}
+
+// shutdown refuses new submissions and waits for the ones in progress to finish, those still
+// running when the context is done are stopped.
+func (t *submissionTracker) shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	t.closing = true
+	t.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		t.stopSubmitting()
+		return nil
+	case <-ctx.Done():
+		t.stopSubmitting()
+		return fmt.Errorf("container submissions still running: %w", ctx.Err())
+	}
+}
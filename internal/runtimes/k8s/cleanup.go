@@ -0,0 +1,96 @@
+package k8s
+
+// Reconciler of the benchmark ConfigMaps whose Job is gone.
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const defaultCleanupInterval = 10 * time.Minute
+
+// configMapReconciler deletes the benchmark ConfigMaps that no Job with their job ID label
+// exists for. A ConfigMap is garbage collected with the Job that owns it, so these are the
+// ConfigMaps whose owner reference could not be set after the Job was created.
+type configMapReconciler struct {
+	logger     *slog.Logger
+	helper     *KubernetesHelper
+	namespaces []string
+	interval   time.Duration
+	now        func() time.Time
+}
+
+// newConfigMapReconciler returns nil when the cleanup is not enabled.
+func newConfigMapReconciler(logger *slog.Logger, helper *KubernetesHelper, namespaces []string, cleanup *config.CleanupConfig) *configMapReconciler {
+	if cleanup == nil || !cleanup.Enabled {
+		return nil
+	}
+	interval := cleanup.Interval
+	if interval <= 0 {
+		interval = defaultCleanupInterval
+	}
+	return &configMapReconciler{
+		logger:     logger,
+		helper:     helper,
+		namespaces: namespaces,
+		interval:   interval,
+		now:        time.Now,
+	}
+}
+
+// run reconciles every interval until the context is done.
+func (c *configMapReconciler) run(ctx context.Context) {
+	c.logger.Info("kubernetes configmap cleanup started", "interval", c.interval.String(), "namespaces", c.namespaces)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.logger.Error("kubernetes configmap cleanup error", "error", err)
+			}
+		}
+	}
+}
+
+// reconcile deletes the orphaned ConfigMaps of every namespace. A ConfigMap younger than the
+// interval is kept, as its Job may not have been created yet.
+func (c *configMapReconciler) reconcile(ctx context.Context) error {
+	var errs []error
+	for _, namespace := range c.namespaces {
+		jobs, err := c.helper.ListJobs(ctx, namespace, labelJobIDKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list jobs in namespace %s: %w", namespace, err))
+			continue
+		}
+		jobIDs := make(map[string]bool, len(jobs))
+		for _, job := range jobs {
+			jobIDs[job.Labels[labelJobIDKey]] = true
+		}
+		configMaps, err := c.helper.ListConfigMaps(ctx, namespace, labelJobIDKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list configmaps in namespace %s: %w", namespace, err))
+			continue
+		}
+		cutoff := c.now().Add(-c.interval)
+		for _, configMap := range configMaps {
+			jobID := configMap.Labels[labelJobIDKey]
+			if jobIDs[jobID] || configMap.CreationTimestamp.Time.After(cutoff) {
+				continue
+			}
+			if err := c.helper.DeleteConfigMap(ctx, namespace, configMap.Name); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("delete configmap %s/%s: %w", namespace, configMap.Name, err))
+				continue
+			}
+			c.logger.Info("kubernetes orphaned configmap deleted", "job_id", jobID, "namespace", namespace, "name", configMap.Name)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/config"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewConfigMapReconcilerDisabled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if reconciler := newConfigMapReconciler(logger, &KubernetesHelper{}, []string{defaultNamespace}, nil); reconciler != nil {
+		t.Fatalf("expected no reconciler without cleanup config")
+	}
+	if reconciler := newConfigMapReconciler(logger, &KubernetesHelper{}, []string{defaultNamespace}, &config.CleanupConfig{Interval: time.Minute}); reconciler != nil {
+		t.Fatalf("expected no reconciler when the cleanup is disabled")
+	}
+	reconciler := newConfigMapReconciler(logger, &KubernetesHelper{}, []string{defaultNamespace}, &config.CleanupConfig{Enabled: true})
+	if reconciler == nil || reconciler.interval != defaultCleanupInterval {
+		t.Fatalf("expected a reconciler with the default interval, got %+v", reconciler)
+	}
+}
+
+func TestConfigMapReconcilerDeletesOrphanedConfigMaps(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := metav1.NewTime(now.Add(-time.Hour))
+	recent := metav1.NewTime(now.Add(-time.Minute))
+	configMap := func(jobID string, created metav1.Time) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:              configMapName(jobID, "bench-1"),
+			Namespace:         defaultNamespace,
+			Labels:            jobLabels(jobID, "provider-1", "bench-1"),
+			CreationTimestamp: created,
+		}}
+	}
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName("running", "bench-1"),
+			Namespace: defaultNamespace,
+			Labels:    jobLabels("running", "provider-1", "bench-1"),
+		}},
+		configMap("running", old),
+		configMap("orphaned", old),
+		configMap("pending", recent),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: defaultNamespace, CreationTimestamp: old}},
+	)
+	reconciler := &configMapReconciler{
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:     &KubernetesHelper{clientset: clientset},
+		namespaces: []string{defaultNamespace},
+		interval:   10 * time.Minute,
+		now:        func() time.Time { return now },
+	}
+
+	if err := reconciler.reconcile(context.Background()); err != nil {
+		t.Fatalf("reconcile returned error: %v", err)
+	}
+	list, err := clientset.CoreV1().ConfigMaps(defaultNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list configmaps: %v", err)
+	}
+	remaining := map[string]bool{}
+	for _, item := range list.Items {
+		remaining[item.Name] = true
+	}
+	if remaining[configMapName("orphaned", "bench-1")] {
+		t.Fatalf("expected the orphaned configmap to be deleted")
+	}
+	for _, name := range []string{configMapName("running", "bench-1"), configMapName("pending", "bench-1"), "unrelated"} {
+		if !remaining[name] {
+			t.Fatalf("expected configmap %s to be kept, got %v", name, remaining)
+		}
+	}
+}
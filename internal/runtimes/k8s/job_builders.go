@@ -3,41 +3,61 @@ package k8s
 // Contains the builder functions that construct Kubernetes objects
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/eval-hub/eval-hub/pkg/api"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
 	maxK8sNameLength                = 63
+	maxConfigMapDataBytes           = 1024 * 1024
 	defaultJobTTLSeconds            = int32(3600)
 	adapterContainerName            = "adapter"
 	jobSpecVolumeName               = "job-spec"
 	dataVolumeName                  = "data"
 	serviceCAVolumeName             = "evalhub-service-ca"
 	jobSpecFileName                 = "job.json"
-	jobSpecMountPath                = "/meta/job.json"
+	defaultConfigMountPath          = "/meta"
 	dataMountPath                   = "/data"
 	serviceCAMountPath              = "/etc/pki/ca-trust/source/anchors"
 	jobPrefix                       = "eval-job-"
 	specSuffix                      = "-spec"
 	envJobIDName                    = "JOB_ID"
 	envEvalHubURLName               = "EVALHUB_URL"
+	envResultsPathName              = "EVALHUB_RESULTS_PATH"
+	envPrefetchDirName              = "EVALHUB_PREFETCH_DIR"
+	prefetchContainerName           = "prefetch"
+	prefetchVolumeName              = "prefetch"
+	scratchVolumeName               = "scratch"
+	defaultPrefetchMountPath        = "/prefetch"
 	defaultAllowPrivilegeEscalation = false
 	defaultRunAsUser                = int64(1000)
 	defaultRunAsGroup               = int64(1000)
 	labelAppKey                     = "app"
 	labelComponentKey               = "component"
-	labelJobIDKey                   = "job_id"
-	labelProviderIDKey              = "provider_id"
-	labelBenchmarkIDKey             = "benchmark_id"
+	labelPrefix                     = "eval-hub/"
+	labelJobIDKey                   = labelPrefix + "job-id"
+	labelProviderIDKey              = labelPrefix + "provider-id"
+	labelBenchmarkIDKey             = labelPrefix + "benchmark-id"
 	labelAppValue                   = "evalhub"
 	labelComponentValue             = "evaluation-job"
 	capabilityDropAll               = "ALL"
+	artifactUploaderContainerName   = "artifact-uploader"
+	defaultArtifactUploaderImage    = "amazon/aws-cli:latest"
+	defaultArtifactUploadTimeout    = 5 * time.Minute
+	envArtifactsURIName             = "ARTIFACTS_URI"
+	// artifactUploadScript idles until Kubernetes stops the uploader, which it does for a
+	// sidecar once the adapter container exited, and then copies the data directory.
+	artifactUploadScript = `trap 'aws s3 cp --recursive --only-show-errors ` + dataMountPath + ` "$` + envArtifactsURIName + `"; exit $?' TERM; ` +
+		`while true; do sleep 1 & wait $!; done`
 )
 
 var dnsLabelSanitizer = regexp.MustCompile(`[^a-z0-9-]+`)
@@ -69,29 +89,61 @@ func buildK8sName(jobID, benchmarkID, suffix string) string {
 }
 
 func buildConfigMap(cfg *jobConfig) *corev1.ConfigMap {
-	labels := jobLabels(cfg.jobID, cfg.providerID, cfg.benchmarkID)
+	labels := resourceLabels(cfg)
 	name := configMapName(cfg.jobID, cfg.benchmarkID)
 	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: cfg.namespace,
-			Labels:    labels,
+			Name:        name,
+			Namespace:   cfg.namespace,
+			Labels:      labels,
+			Annotations: resourceAnnotations(cfg),
 		},
 		Data: map[string]string{
-			jobSpecFileName: cfg.jobSpecJSON,
+			cfg.specFileName(): cfg.jobSpecJSON,
 		},
 	}
 }
 
+// configMapDataSize returns the number of bytes the data of the ConfigMap takes, which is
+// what the API server compares against the 1MiB ConfigMap limit.
+func configMapDataSize(configMap *corev1.ConfigMap) int {
+	size := 0
+	for key, value := range configMap.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range configMap.BinaryData {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+func validateConfigMapSize(cfg *jobConfig) error {
+	size := configMapDataSize(buildConfigMap(cfg))
+	if size > maxConfigMapDataBytes {
+		return fmt.Errorf("benchmark %s config exceeds ConfigMap size limit (%d bytes, limit %d bytes)",
+			cfg.benchmarkID, size, maxConfigMapDataBytes)
+	}
+	return nil
+}
+
 func buildJob(cfg *jobConfig) (*batchv1.Job, error) {
 	if cfg.adapterImage == "" {
 		return nil, fmt.Errorf("adapter image is required")
 	}
-	labels := jobLabels(cfg.jobID, cfg.providerID, cfg.benchmarkID)
+	labels := resourceLabels(cfg)
+	annotations := resourceAnnotations(cfg)
 	jobName := jobName(cfg.jobID, cfg.benchmarkID)
 	configMap := configMapName(cfg.jobID, cfg.benchmarkID)
 
 	ttl := defaultJobTTLSeconds
+	if cfg.ttlAfterFinished != nil {
+		ttl = *cfg.ttlAfterFinished
+	}
+	restartPolicy := cfg.restartPolicy
+	if restartPolicy == "" {
+		restartPolicy = corev1.RestartPolicyNever
+	}
 	backoff := int32(cfg.retryAttempts)
 
 	envVars := buildEnvVars(cfg)
@@ -122,8 +174,8 @@ func buildJob(cfg *jobConfig) (*batchv1.Job, error) {
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      jobSpecVolumeName,
-			MountPath: jobSpecMountPath,
-			SubPath:   jobSpecFileName,
+			MountPath: cfg.specFilePath(),
+			SubPath:   cfg.specFileName(),
 			ReadOnly:  true,
 		},
 		{
@@ -142,41 +194,111 @@ func buildJob(cfg *jobConfig) (*batchv1.Job, error) {
 	// Set ServiceAccount if configured
 	// applied below in template spec
 
+	var initContainers []corev1.Container
+	if cfg.initContainer != nil {
+		mount := corev1.VolumeMount{Name: prefetchVolumeName, MountPath: prefetchMountPath(cfg.initContainer)}
+		volumes = append(volumes, corev1.Volume{
+			Name:         prefetchVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		volumeMounts = append(volumeMounts, mount)
+		envVars = append(envVars, corev1.EnvVar{Name: envPrefetchDirName, Value: mount.MountPath})
+		initContainers = append(initContainers, buildPrefetchContainer(cfg, mount, resources))
+	}
+	if cfg.scratchVolume != nil {
+		sizeLimit, err := resource.ParseQuantity(cfg.scratchVolume.SizeLimit)
+		if err != nil {
+			return nil, fmt.Errorf("parse scratch volume size limit: %w", err)
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name:         scratchVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit}},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: scratchVolumeName, MountPath: path.Clean(cfg.scratchVolume.MountPath)})
+	}
+	var terminationGracePeriod *int64
+	if cfg.artifacts != nil {
+		initContainers = append(initContainers, buildArtifactUploader(cfg))
+		terminationGracePeriod = int64Ptr(int64(artifactUploadTimeout(cfg.artifacts).Seconds()))
+	}
+
 	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobName,
-			Namespace: cfg.namespace,
-			Labels:    labels,
+			Name:        jobName,
+			Namespace:   cfg.namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: batchv1.JobSpec{
 			BackoffLimit:            &backoff,
+			ActiveDeadlineSeconds:   cfg.activeDeadline,
 			TTLSecondsAfterFinished: &ttl,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyNever,
+					RestartPolicy:                 restartPolicy,
+					PriorityClassName:             cfg.priorityClassName,
+					TerminationGracePeriodSeconds: terminationGracePeriod,
+					InitContainers:                initContainers,
 					Containers: []corev1.Container{
 						{
 							Name:            adapterContainerName,
 							Image:           cfg.adapterImage,
-							ImagePullPolicy: corev1.PullAlways,
+							ImagePullPolicy: cfg.imagePullPolicy,
 							Command:         buildContainerCommand(cfg.entrypoint),
 							Env:             envVars,
 							Resources:       resources,
 							SecurityContext: defaultSecurityContext(),
 							VolumeMounts:    volumeMounts,
+							// The adapter writes the scores of the benchmark to the
+							// results file.
+							TerminationMessagePath:   cfg.resultsFilePath(),
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 						},
 					},
 					Volumes:            volumes,
 					ServiceAccountName: cfg.serviceAccountName,
+					ImagePullSecrets:   buildImagePullSecrets(cfg.imagePullSecrets),
 				},
 			},
 		},
 	}, nil
 }
 
+// buildPrefetchContainer builds the init container of the provider, it runs to completion
+// before the adapter starts and shares the prefetch volume with it.
+func buildPrefetchContainer(cfg *jobConfig, mount corev1.VolumeMount, resources corev1.ResourceRequirements) corev1.Container {
+	var env []corev1.EnvVar
+	for _, item := range cfg.initContainer.Env {
+		if item.Name == "" {
+			continue
+		}
+		env = append(env, corev1.EnvVar{Name: item.Name, Value: item.Value})
+	}
+	env = append(env, corev1.EnvVar{Name: envPrefetchDirName, Value: mount.MountPath})
+	return corev1.Container{
+		Name:            prefetchContainerName,
+		Image:           cfg.initContainer.Image,
+		ImagePullPolicy: cfg.imagePullPolicy,
+		Command:         buildContainerCommand(cfg.initContainer.Command),
+		Env:             env,
+		Resources:       resources,
+		SecurityContext: defaultSecurityContext(),
+		VolumeMounts:    []corev1.VolumeMount{mount},
+	}
+}
+
+func prefetchMountPath(initContainer *api.K8sInitContainer) string {
+	if initContainer.MountPath == "" {
+		return defaultPrefetchMountPath
+	}
+	return path.Clean(initContainer.MountPath)
+}
+
 func ensureServiceCAVolume(volumes []corev1.Volume, configMapName string) []corev1.Volume {
 	for _, volume := range volumes {
 		if volume.Name == serviceCAVolumeName {
@@ -268,6 +390,13 @@ func buildEnvVars(cfg *jobConfig) []corev1.EnvVar {
 		seen[envEvalHubURLName] = true
 	}
 
+	// Add EVALHUB_RESULTS_PATH
+	env = append(env, corev1.EnvVar{
+		Name:  envResultsPathName,
+		Value: cfg.resultsFilePath(),
+	})
+	seen[envResultsPathName] = true
+
 	// Add provider-specific environment variables
 	for _, item := range cfg.defaultEnv {
 		if item.Name == "" || seen[item.Name] {
@@ -324,6 +453,64 @@ func buildResources(cfg *jobConfig) (corev1.ResourceRequirements, error) {
 	return resources, nil
 }
 
+// buildArtifactUploader builds the sidecar that uploads the data directory of the benchmark
+// to the artifact sink. It is an init container that is restarted, so Kubernetes stops it
+// after the adapter container exited instead of waiting for it.
+func buildArtifactUploader(cfg *jobConfig) corev1.Container {
+	sink := cfg.artifacts
+	env := []corev1.EnvVar{
+		{Name: envArtifactsURIName, Value: sink.BenchmarkURI(cfg.jobID, cfg.benchmarkID)},
+		{Name: "HOME", Value: "/tmp"},
+	}
+	if sink.Endpoint != "" {
+		env = append(env, corev1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: sink.Endpoint})
+	}
+	if sink.Region != "" {
+		env = append(env, corev1.EnvVar{Name: "AWS_REGION", Value: sink.Region})
+	}
+	var envFrom []corev1.EnvFromSource
+	if sink.CredentialsSecret != "" {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: sink.CredentialsSecret}},
+		})
+	}
+	restartAlways := corev1.ContainerRestartPolicyAlways
+	return corev1.Container{
+		Name:            artifactUploaderContainerName,
+		Image:           defaultIfEmpty(sink.UploaderImage, defaultArtifactUploaderImage),
+		Command:         []string{"/bin/sh", "-c", artifactUploadScript},
+		Env:             env,
+		EnvFrom:         envFrom,
+		RestartPolicy:   &restartAlways,
+		SecurityContext: defaultSecurityContext(),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      dataVolumeName,
+				MountPath: dataMountPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+func artifactUploadTimeout(sink *api.ArtifactSink) time.Duration {
+	if sink.UploadTimeout > 0 {
+		return sink.UploadTimeout
+	}
+	return defaultArtifactUploadTimeout
+}
+
+func buildImagePullSecrets(names []string) []corev1.LocalObjectReference {
+	var secrets []corev1.LocalObjectReference
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			secrets = append(secrets, corev1.LocalObjectReference{Name: name})
+		}
+	}
+	return secrets
+}
+
 func jobName(jobID, benchmarkID string) string {
 	return buildK8sName(jobID, benchmarkID, "")
 }
@@ -341,3 +528,37 @@ func jobLabels(jobID, providerID, benchmarkID string) map[string]string {
 		labelBenchmarkIDKey: benchmarkID,
 	}
 }
+
+// resourceLabels returns the labels of the provider with the eval-hub labels of the
+// benchmark on top.
+func resourceLabels(cfg *jobConfig) map[string]string {
+	labels := make(map[string]string, len(cfg.labels)+5)
+	for key, value := range cfg.labels {
+		labels[key] = value
+	}
+	for key, value := range jobLabels(cfg.jobID, cfg.providerID, cfg.benchmarkID) {
+		labels[key] = value
+	}
+	return labels
+}
+
+func resourceAnnotations(cfg *jobConfig) map[string]string {
+	if len(cfg.annotations) == 0 {
+		return nil
+	}
+	annotations := make(map[string]string, len(cfg.annotations))
+	for key, value := range cfg.annotations {
+		annotations[key] = value
+	}
+	return annotations
+}
+
+// isReservedLabel reports whether the label is set by eval-hub on every resource.
+func isReservedLabel(key string) bool {
+	return key == labelAppKey || key == labelComponentKey || strings.HasPrefix(key, labelPrefix)
+}
+
+// benchmarkSelector selects the resources created for the benchmark of the job.
+func benchmarkSelector(jobID, benchmarkID string) string {
+	return labels.SelectorFromSet(labels.Set{labelJobIDKey: jobID, labelBenchmarkIDKey: benchmarkID}).String()
+}
@@ -1,9 +1,12 @@
 package k8s
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestBuildConfigMap(t *testing.T) {
@@ -25,6 +28,28 @@ func TestBuildConfigMap(t *testing.T) {
 	}
 }
 
+func TestValidateConfigMapSize(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:       "job-123",
+		namespace:   "default",
+		providerID:  "provider-1",
+		benchmarkID: "bench-1",
+		jobSpecJSON: "{}",
+	}
+	if err := validateConfigMapSize(cfg); err != nil {
+		t.Fatalf("expected small config to pass, got %v", err)
+	}
+
+	cfg.jobSpecJSON = strings.Repeat("x", maxConfigMapDataBytes)
+	err := validateConfigMapSize(cfg)
+	if err == nil {
+		t.Fatalf("expected error for oversized config")
+	}
+	if !strings.Contains(err.Error(), "benchmark bench-1 config exceeds ConfigMap size limit") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestBuildK8sNameSanitizes(t *testing.T) {
 	name := buildK8sName("Job-123", "AraDiCE_boolq_lev", "")
 	if name != "eval-job-job-123-aradice-boolq-lev" {
@@ -108,3 +133,326 @@ func TestContainerCommandTrimsEmptyItems(t *testing.T) {
 		t.Fatalf("unexpected command: %v", command)
 	}
 }
+
+func TestBuildJobActiveDeadline(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:          "job-123",
+		namespace:      "default",
+		providerID:     "provider-1",
+		benchmarkID:    "bench-1",
+		adapterImage:   "adapter:latest",
+		retryAttempts:  2,
+		activeDeadline: int64Ptr(600),
+	}
+
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != 600 {
+		t.Fatalf("expected activeDeadlineSeconds 600, got %v", job.Spec.ActiveDeadlineSeconds)
+	}
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 2 {
+		t.Fatalf("expected backoffLimit 2, got %v", job.Spec.BackoffLimit)
+	}
+
+	cfg.activeDeadline = nil
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		t.Fatalf("expected no activeDeadlineSeconds when no timeout is configured")
+	}
+}
+
+func TestBuildJobMergesProviderLabelsAndAnnotations(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:        "job-123",
+		namespace:    "default",
+		providerID:   "provider-1",
+		benchmarkID:  "bench-1",
+		adapterImage: "adapter:latest",
+		labels:       map[string]string{"team": "evaluation"},
+		annotations:  map[string]string{"example.com/owner": "evaluation-team"},
+	}
+
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	configMap := buildConfigMap(cfg)
+	for kind, meta := range map[string]metav1.ObjectMeta{
+		"job":       job.ObjectMeta,
+		"pod":       job.Spec.Template.ObjectMeta,
+		"configmap": configMap.ObjectMeta,
+	} {
+		if meta.Labels[labelJobIDKey] != "job-123" || meta.Labels[labelBenchmarkIDKey] != "bench-1" ||
+			meta.Labels[labelProviderIDKey] != "provider-1" || meta.Labels["team"] != "evaluation" {
+			t.Fatalf("unexpected %s labels %v", kind, meta.Labels)
+		}
+		if meta.Annotations["example.com/owner"] != "evaluation-team" {
+			t.Fatalf("unexpected %s annotations %v", kind, meta.Annotations)
+		}
+	}
+}
+
+func TestValidateResourceMetadata(t *testing.T) {
+	if err := validateResourceMetadata(map[string]string{"team": "evaluation"}, map[string]string{"example.com/owner": "any value"}); err != nil {
+		t.Fatalf("expected valid metadata, got %v", err)
+	}
+	err := validateResourceMetadata(
+		map[string]string{"eval-hub/job-id": "x", "bad key": "x", "team": "not valid!"},
+		map[string]string{"-bad": "x"},
+	)
+	if err == nil {
+		t.Fatalf("expected invalid metadata to be rejected")
+	}
+	for _, expected := range []string{`label "eval-hub/job-id" is reserved`, `label key "bad key"`, `label "team" value "not valid!"`, `annotations: Invalid value: "-bad"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected %q in %v", expected, err)
+		}
+	}
+
+	err = validateResourceMetadata(nil, map[string]string{"example.com/notes": strings.Repeat("x", 256*1024)})
+	if err == nil || !strings.Contains(err.Error(), "annotations: Too long") {
+		t.Fatalf("expected oversized annotations to be rejected, got %v", err)
+	}
+}
+
+func TestBuildJobAddsArtifactUploader(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:        "job-123",
+		namespace:    "default",
+		providerID:   "provider-1",
+		benchmarkID:  "bench-1",
+		adapterImage: "adapter:latest",
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(job.Spec.Template.Spec.InitContainers) != 0 || job.Spec.Template.Spec.TerminationGracePeriodSeconds != nil {
+		t.Fatalf("expected no uploader without an artifact sink")
+	}
+
+	cfg.artifacts = &api.ArtifactSink{Bucket: "eval-artifacts", Prefix: "/eval-hub/", Endpoint: "https://s3.example.com", CredentialsSecret: "s3-credentials"}
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.InitContainers) != 1 {
+		t.Fatalf("expected the uploader sidecar, got %d init containers", len(podSpec.InitContainers))
+	}
+	uploader := podSpec.InitContainers[0]
+	if uploader.RestartPolicy == nil || *uploader.RestartPolicy != corev1.ContainerRestartPolicyAlways || uploader.Image != defaultArtifactUploaderImage {
+		t.Fatalf("expected a restartable uploader with the default image, got %+v", uploader)
+	}
+	env := map[string]string{}
+	for _, envVar := range uploader.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	if env[envArtifactsURIName] != "s3://eval-artifacts/eval-hub/job-123/bench-1/" || env["AWS_ENDPOINT_URL"] != "https://s3.example.com" {
+		t.Fatalf("unexpected uploader env %v", env)
+	}
+	if len(uploader.EnvFrom) != 1 || uploader.EnvFrom[0].SecretRef.Name != "s3-credentials" {
+		t.Fatalf("expected the credentials secret, got %+v", uploader.EnvFrom)
+	}
+	if len(uploader.VolumeMounts) != 1 || uploader.VolumeMounts[0].Name != dataVolumeName || !uploader.VolumeMounts[0].ReadOnly {
+		t.Fatalf("expected the data volume to be mounted read only, got %+v", uploader.VolumeMounts)
+	}
+	if podSpec.TerminationGracePeriodSeconds == nil || *podSpec.TerminationGracePeriodSeconds != 300 {
+		t.Fatalf("expected the upload timeout as grace period, got %v", podSpec.TerminationGracePeriodSeconds)
+	}
+}
+
+func TestValidateArtifactSink(t *testing.T) {
+	if err := validateArtifactSink(nil); err != nil {
+		t.Fatalf("expected no sink to be valid, got %v", err)
+	}
+	if err := validateArtifactSink(&api.ArtifactSink{Bucket: "eval-artifacts"}); err != nil {
+		t.Fatalf("expected a bucket to be enough, got %v", err)
+	}
+	err := validateArtifactSink(&api.ArtifactSink{UploadTimeout: -1})
+	if err == nil || !strings.Contains(err.Error(), "bucket is required") || !strings.Contains(err.Error(), "upload_timeout") {
+		t.Fatalf("expected bucket and timeout errors, got %v", err)
+	}
+}
+
+func TestBuildJobWritesResultsToTerminationMessage(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:        "job-123",
+		namespace:    "default",
+		providerID:   "provider-1",
+		benchmarkID:  "bench-1",
+		adapterImage: "adapter:latest",
+	}
+
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	adapter := job.Spec.Template.Spec.Containers[0]
+	if adapter.TerminationMessagePath != "/meta/results.json" {
+		t.Fatalf("unexpected termination message path %q", adapter.TerminationMessagePath)
+	}
+	found := false
+	for _, env := range adapter.Env {
+		if env.Name == envResultsPathName && env.Value == adapter.TerminationMessagePath {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the adapter to be told where to write its results, got %+v", adapter.Env)
+	}
+}
+
+func TestBuildJobInitContainer(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:        "job-123",
+		namespace:    "default",
+		providerID:   "provider-1",
+		benchmarkID:  "bench-1",
+		adapterImage: "adapter:latest",
+		initContainer: &api.K8sInitContainer{
+			Image:   "prefetch:latest",
+			Command: []string{"/bin/prefetch", "mmlu"},
+			Env:     []api.EnvVar{{Name: "DATASETS", Value: "mmlu"}},
+		},
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.InitContainers) != 1 {
+		t.Fatalf("expected one init container, got %d", len(podSpec.InitContainers))
+	}
+	prefetch := podSpec.InitContainers[0]
+	if prefetch.Image != "prefetch:latest" || len(prefetch.Command) != 2 || prefetch.RestartPolicy != nil {
+		t.Fatalf("unexpected init container %+v", prefetch)
+	}
+	if len(prefetch.VolumeMounts) != 1 || prefetch.VolumeMounts[0].MountPath != "/prefetch" {
+		t.Fatalf("expected the prefetch volume to be mounted, got %+v", prefetch.VolumeMounts)
+	}
+
+	adapter := podSpec.Containers[0]
+	mounted := false
+	for _, mount := range adapter.VolumeMounts {
+		if mount.Name == prefetch.VolumeMounts[0].Name && mount.MountPath == "/prefetch" {
+			mounted = true
+		}
+	}
+	if !mounted {
+		t.Fatalf("expected the adapter to mount the prefetch volume, got %+v", adapter.VolumeMounts)
+	}
+	found := false
+	for _, env := range adapter.Env {
+		if env.Name == envPrefetchDirName && env.Value == "/prefetch" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the adapter to be told where the prefetched data is, got %+v", adapter.Env)
+	}
+
+	cfg.initContainer = nil
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if len(job.Spec.Template.Spec.InitContainers) != 0 || len(job.Spec.Template.Spec.Volumes) != 2 {
+		t.Fatalf("expected no init container without configuration")
+	}
+}
+
+// defaultConfigFile is where the job spec is mounted when the provider does not configure it.
+const defaultConfigFile = defaultConfigMountPath + "/" + jobSpecFileName
+
+func TestValidateInitContainer(t *testing.T) {
+	if err := validateInitContainer(nil, defaultConfigFile); err != nil {
+		t.Fatalf("expected no error without init container, got %v", err)
+	}
+	if err := validateInitContainer(&api.K8sInitContainer{Image: "prefetch:latest", MountPath: "/datasets"}, defaultConfigFile); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err := validateInitContainer(&api.K8sInitContainer{MountPath: "datasets"}, defaultConfigFile)
+	if err == nil || !strings.Contains(err.Error(), "image is required") || !strings.Contains(err.Error(), "must be absolute") {
+		t.Fatalf("expected both errors, got %v", err)
+	}
+	err = validateInitContainer(&api.K8sInitContainer{Image: "prefetch:latest", MountPath: "/data/"}, defaultConfigFile)
+	if err == nil || !strings.Contains(err.Error(), "used by the adapter") {
+		t.Fatalf("expected a mount path conflict, got %v", err)
+	}
+}
+
+func TestBuildJobScratchVolume(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:         "job-123",
+		namespace:     "default",
+		providerID:    "provider-1",
+		benchmarkID:   "bench-1",
+		adapterImage:  "adapter:latest",
+		scratchVolume: &api.K8sScratchVolume{MountPath: "/scratch/", SizeLimit: "10Gi"},
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	podSpec := job.Spec.Template.Spec
+	var scratch *corev1.Volume
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].Name == scratchVolumeName {
+			scratch = &podSpec.Volumes[i]
+		}
+	}
+	if scratch == nil || scratch.EmptyDir == nil || scratch.EmptyDir.SizeLimit == nil || scratch.EmptyDir.SizeLimit.String() != "10Gi" {
+		t.Fatalf("expected an emptyDir limited to 10Gi, got %+v", scratch)
+	}
+	mounted := false
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.Name == scratchVolumeName && mount.MountPath == "/scratch" {
+			mounted = true
+		}
+	}
+	if !mounted {
+		t.Fatalf("expected the adapter to mount the scratch volume, got %+v", podSpec.Containers[0].VolumeMounts)
+	}
+
+	cfg.scratchVolume = nil
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if len(job.Spec.Template.Spec.Volumes) != 2 || len(job.Spec.Template.Spec.Containers[0].VolumeMounts) != 2 {
+		t.Fatalf("expected no scratch volume without configuration")
+	}
+}
+
+func TestValidateScratchVolume(t *testing.T) {
+	if err := validateScratchVolume(nil, nil, defaultConfigFile); err != nil {
+		t.Fatalf("expected no error without scratch volume, got %v", err)
+	}
+	if err := validateScratchVolume(&api.K8sScratchVolume{MountPath: "/meta/scratch", SizeLimit: "500Mi"}, nil, defaultConfigFile); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err := validateScratchVolume(&api.K8sScratchVolume{MountPath: "scratch", SizeLimit: "lots"}, nil, defaultConfigFile)
+	if err == nil || !strings.Contains(err.Error(), "must be absolute") || !strings.Contains(err.Error(), `size_limit "lots"`) {
+		t.Fatalf("expected both errors, got %v", err)
+	}
+	for _, mountPath := range []string{"/meta", "/meta/job.json", "/data/tmp", "/"} {
+		err := validateScratchVolume(&api.K8sScratchVolume{MountPath: mountPath, SizeLimit: "1Gi"}, nil, defaultConfigFile)
+		if err == nil || !strings.Contains(err.Error(), "collides with") {
+			t.Fatalf("expected %s to collide with a mount of the adapter, got %v", mountPath, err)
+		}
+	}
+	err = validateScratchVolume(&api.K8sScratchVolume{MountPath: "/prefetch", SizeLimit: "1Gi"}, &api.K8sInitContainer{Image: "prefetch:latest"}, defaultConfigFile)
+	if err == nil || !strings.Contains(err.Error(), "collides with /prefetch") {
+		t.Fatalf("expected a collision with the prefetch volume, got %v", err)
+	}
+	err = validateScratchVolume(&api.K8sScratchVolume{MountPath: "/scratch", SizeLimit: "-1Gi"}, nil, defaultConfigFile)
+	if err == nil || !strings.Contains(err.Error(), "must be positive") {
+		t.Fatalf("expected a non-positive size limit to be rejected, got %v", err)
+	}
+}
@@ -3,11 +3,20 @@ package k8s
 // Contains the configuration logic that prepares the data needed by the builders
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
@@ -30,6 +39,8 @@ type jobConfig struct {
 	providerID          string
 	benchmarkID         string
 	retryAttempts       int
+	activeDeadline      *int64
+	ttlAfterFinished    *int32
 	adapterImage        string
 	entrypoint          []string
 	defaultEnv          []api.EnvVar
@@ -42,6 +53,17 @@ type jobConfig struct {
 	serviceCAConfigMap  string
 	evalHubURL          string
 	evalHubInstanceName string
+	labels              map[string]string
+	annotations         map[string]string
+	imagePullPolicy     corev1.PullPolicy
+	imagePullSecrets    []string
+	artifacts           *api.ArtifactSink
+	initContainer       *api.K8sInitContainer
+	scratchVolume       *api.K8sScratchVolume
+	configMountPath     string
+	configFileName      string
+	priorityClassName   string
+	restartPolicy       corev1.RestartPolicy
 }
 
 type jobSpec struct {
@@ -71,6 +93,31 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 	if runtime.K8s.Image == "" {
 		return nil, fmt.Errorf("runtime adapter image is required")
 	}
+	imagePullPolicy, err := resolveImagePullPolicy(runtime.K8s.ImagePullPolicy)
+	if err != nil {
+		return nil, err
+	}
+	configMountPath, configFileName, err := resolveConfigFile(runtime.K8s)
+	if err != nil {
+		return nil, err
+	}
+	configFile := path.Join(configMountPath, configFileName)
+	if err := validateInitContainer(runtime.K8s.InitContainer, configFile); err != nil {
+		return nil, err
+	}
+	if err := validateScratchVolume(runtime.K8s.ScratchVolume, runtime.K8s.InitContainer, configFile); err != nil {
+		return nil, err
+	}
+	restartPolicy, err := resolveRestartPolicy(runtime.K8s.RestartPolicy)
+	if err != nil {
+		return nil, err
+	}
+	priorityClassName := strings.TrimSpace(runtime.K8s.PriorityClassName)
+	if priorityClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(priorityClassName); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid priority class name %q: %s", priorityClassName, strings.Join(errs, "; "))
+		}
+	}
 	if evaluation.Model.URL == "" || evaluation.Model.Name == "" {
 		return nil, fmt.Errorf("model url and name are required")
 	}
@@ -79,14 +126,18 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		return nil, fmt.Errorf("%s is required", serviceURLEnv)
 	}
 
-	retryAttempts := 0
-	if evaluation.RetryAttempts != nil {
-		if *evaluation.RetryAttempts < 0 {
-			return nil, fmt.Errorf("retry attempts cannot be negative")
-		}
-		retryAttempts = *evaluation.RetryAttempts
+	retryAttempts, err := resolveRetryAttempts(evaluation.RetryAttempts, runtime.K8s.BackoffLimit)
+	if err != nil {
+		return nil, err
+	}
+	timeoutSeconds, err := resolveTimeoutSeconds(evaluation.TimeoutMinutes, runtime.K8s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := resolveNamespace(runtime.K8s.Namespace)
+	if err != nil {
+		return nil, err
 	}
-	namespace := resolveNamespace("")
 	benchmarkConfig, err := findBenchmarkConfig(evaluation, benchmarkID)
 	if err != nil {
 		return nil, err
@@ -97,7 +148,6 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 	if len(benchmarkParams) == 0 {
 		return nil, fmt.Errorf("benchmark_config is required")
 	}
-	timeoutSeconds := timeoutSecondsFromMinutes(evaluation.TimeoutMinutes)
 	spec := jobSpec{
 		JobID:           evaluation.Resource.ID,
 		BenchmarkID:     benchmarkID,
@@ -136,6 +186,8 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		providerID:          provider.ProviderID,
 		benchmarkID:         benchmarkID,
 		retryAttempts:       retryAttempts,
+		activeDeadline:      activeDeadlineFromSeconds(timeoutSeconds),
+		ttlAfterFinished:    runtime.K8s.TTLSecondsAfterFinished,
 		adapterImage:        runtime.K8s.Image,
 		entrypoint:          runtime.K8s.Entrypoint,
 		defaultEnv:          runtime.K8s.Env,
@@ -148,9 +200,204 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		serviceCAConfigMap:  serviceCAConfigMap,
 		evalHubURL:          evalHubURL,
 		evalHubInstanceName: evalHubInstanceName,
+		labels:              runtime.K8s.Labels,
+		annotations:         runtime.K8s.Annotations,
+		imagePullPolicy:     imagePullPolicy,
+		imagePullSecrets:    runtime.K8s.ImagePullSecrets,
+		initContainer:       runtime.K8s.InitContainer,
+		scratchVolume:       runtime.K8s.ScratchVolume,
+		configMountPath:     configMountPath,
+		configFileName:      configFileName,
+		priorityClassName:   priorityClassName,
+		restartPolicy:       restartPolicy,
 	}, nil
 }
 
+// validateArtifactSink checks the artifact sink when one is configured.
+func validateArtifactSink(sink *api.ArtifactSink) error {
+	if sink == nil {
+		return nil
+	}
+	var errs []error
+	if strings.TrimSpace(sink.Bucket) == "" {
+		errs = append(errs, fmt.Errorf("artifacts bucket is required"))
+	} else if strings.Contains(sink.Bucket, "/") {
+		errs = append(errs, fmt.Errorf("artifacts bucket %q must not contain '/'", sink.Bucket))
+	}
+	if sink.UploadTimeout < 0 {
+		errs = append(errs, fmt.Errorf("artifacts upload_timeout must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// specFileName is the name of the job spec file, and its key in the ConfigMap.
+func (cfg *jobConfig) specFileName() string {
+	return defaultIfEmpty(cfg.configFileName, jobSpecFileName)
+}
+
+// specFilePath is where the job spec file is mounted in the adapter container.
+func (cfg *jobConfig) specFilePath() string {
+	return path.Join(defaultIfEmpty(cfg.configMountPath, defaultConfigMountPath), cfg.specFileName())
+}
+
+// resultsFilePath is where the adapter writes its results file, next to the job spec. It is
+// the termination message of the adapter container, the kubelet reports its content, up to
+// api.MaxBenchmarkResultsSize, in the status of the pod once the adapter exited.
+func (cfg *jobConfig) resultsFilePath() string {
+	return resultsFilePath(defaultIfEmpty(cfg.configMountPath, defaultConfigMountPath))
+}
+
+func resultsFilePath(configMountPath string) string {
+	return path.Join(configMountPath, api.BenchmarkResultsFile)
+}
+
+// resolveConfigFile returns the directory the job spec of the provider is mounted in and the
+// name of its file, the defaults when they are not configured. The file must not hide the
+// other files and directories of the adapter.
+func resolveConfigFile(runtime *api.K8sRuntime) (string, string, error) {
+	mountPath := defaultIfEmpty(strings.TrimSpace(runtime.ConfigMountPath), defaultConfigMountPath)
+	fileName := defaultIfEmpty(strings.TrimSpace(runtime.ConfigFileName), jobSpecFileName)
+	var errs []error
+	if !path.IsAbs(mountPath) {
+		errs = append(errs, fmt.Errorf("config_mount_path %q must be absolute", mountPath))
+	}
+	if invalid := validation.IsConfigMapKey(fileName); len(invalid) > 0 {
+		errs = append(errs, fmt.Errorf("invalid config_file_name %q: %s", fileName, strings.Join(invalid, "; ")))
+	}
+	if len(errs) == 0 {
+		configFile := path.Join(mountPath, fileName)
+		for _, mounted := range []string{resultsFilePath(path.Clean(mountPath)), dataMountPath, serviceCAMountPath} {
+			if mountPathsOverlap(configFile, mounted) {
+				errs = append(errs, fmt.Errorf("config file %q collides with %s", configFile, mounted))
+			}
+		}
+	}
+	return path.Clean(mountPath), fileName, errors.Join(errs...)
+}
+
+// validateInitContainer checks the init container of a provider when one is configured.
+func validateInitContainer(initContainer *api.K8sInitContainer, configFile string) error {
+	if initContainer == nil {
+		return nil
+	}
+	var errs []error
+	if strings.TrimSpace(initContainer.Image) == "" {
+		errs = append(errs, fmt.Errorf("init container image is required"))
+	}
+	if initContainer.MountPath != "" && !path.IsAbs(initContainer.MountPath) {
+		errs = append(errs, fmt.Errorf("init container mount_path %q must be absolute", initContainer.MountPath))
+	}
+	switch path.Clean(initContainer.MountPath) {
+	case dataMountPath, configFile, path.Dir(configFile):
+		errs = append(errs, fmt.Errorf("init container mount_path %q is used by the adapter", initContainer.MountPath))
+	}
+	return errors.Join(errs...)
+}
+
+// validateScratchVolume checks the scratch volume of a provider when one is configured. Its
+// mount path must not contain, or be inside, a path the adapter already mounts.
+func validateScratchVolume(scratch *api.K8sScratchVolume, initContainer *api.K8sInitContainer, configFile string) error {
+	if scratch == nil {
+		return nil
+	}
+	var errs []error
+	if !path.IsAbs(scratch.MountPath) {
+		errs = append(errs, fmt.Errorf("scratch volume mount_path %q must be absolute", scratch.MountPath))
+	} else {
+		mounted := []string{configFile, resultsFilePath(path.Dir(configFile)), dataMountPath, serviceCAMountPath}
+		if initContainer != nil {
+			mounted = append(mounted, prefetchMountPath(initContainer))
+		}
+		for _, mountPath := range mounted {
+			if mountPathsOverlap(path.Clean(scratch.MountPath), mountPath) {
+				errs = append(errs, fmt.Errorf("scratch volume mount_path %q collides with %s", scratch.MountPath, mountPath))
+			}
+		}
+	}
+	if strings.TrimSpace(scratch.SizeLimit) == "" {
+		errs = append(errs, fmt.Errorf("scratch volume size_limit is required"))
+	} else if quantity, err := resource.ParseQuantity(scratch.SizeLimit); err != nil {
+		errs = append(errs, fmt.Errorf("scratch volume size_limit %q: %w", scratch.SizeLimit, err))
+	} else if quantity.Sign() <= 0 {
+		errs = append(errs, fmt.Errorf("scratch volume size_limit %q must be positive", scratch.SizeLimit))
+	}
+	return errors.Join(errs...)
+}
+
+// mountPathsOverlap reports whether one of the clean absolute paths is the other or one of
+// its parent directories.
+func mountPathsOverlap(a string, b string) bool {
+	if a == b || a == "/" || b == "/" {
+		return true
+	}
+	return strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+// resolveImagePullPolicy returns the configured pull policy of the adapter image, Always when
+// none is configured.
+func resolveImagePullPolicy(configured string) (corev1.PullPolicy, error) {
+	switch policy := corev1.PullPolicy(strings.TrimSpace(configured)); policy {
+	case "":
+		return corev1.PullAlways, nil
+	case corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("image pull policy %q must be one of %s, %s or %s", configured, corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever)
+	}
+}
+
+// validateImagePullSecrets checks that the names of the image pull secrets are valid secret
+// names, the empty names are skipped when the pod is built.
+func validateImagePullSecrets(names []string) error {
+	var errs []error
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			errs = append(errs, fmt.Errorf("image pull secret %q: %s", name, msg))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveRestartPolicy returns the configured restart policy of the benchmark pods, Never when
+// none is configured. A Job only allows Never and OnFailure.
+func resolveRestartPolicy(configured string) (corev1.RestartPolicy, error) {
+	switch policy := corev1.RestartPolicy(strings.TrimSpace(configured)); policy {
+	case "":
+		return corev1.RestartPolicyNever, nil
+	case corev1.RestartPolicyNever, corev1.RestartPolicyOnFailure:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("restart policy %q must be one of %s or %s", configured, corev1.RestartPolicyNever, corev1.RestartPolicyOnFailure)
+	}
+}
+
+// validateResourceMetadata checks the labels and annotations of a provider against the
+// Kubernetes constraints, the eval-hub labels are reserved.
+func validateResourceMetadata(labels map[string]string, annotations map[string]string) error {
+	var errs []error
+	for key, value := range labels {
+		if isReservedLabel(key) {
+			errs = append(errs, fmt.Errorf("label %q is reserved", key))
+			continue
+		}
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Errorf("label key %q: %s", key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = append(errs, fmt.Errorf("label %q value %q: %s", key, value, msg))
+		}
+	}
+	// the annotation keys and the total size of the annotations
+	for _, fieldErr := range apivalidation.ValidateAnnotations(annotations, field.NewPath("annotations")) {
+		errs = append(errs, fieldErr)
+	}
+	return errors.Join(errs...)
+}
+
 func defaultIfEmpty(value string, fallback string) string {
 	if value == "" {
 		return fallback
@@ -158,15 +405,27 @@ func defaultIfEmpty(value string, fallback string) string {
 	return value
 }
 
-func resolveNamespace(configured string) string {
-	if configured != "" {
-		return configured
+// resolveNamespace returns the namespace for the job resources: the configured
+// namespace, then the namespace of the service pod, then "default".
+func resolveNamespace(configured string) (string, error) {
+	namespace := strings.TrimSpace(configured)
+	if namespace == "" {
+		namespace = readInClusterNamespace()
 	}
-	inClusterNamespace := readInClusterNamespace()
-	if inClusterNamespace != "" {
-		return inClusterNamespace
+	if namespace == "" {
+		namespace = defaultNamespace
 	}
-	return defaultNamespace
+	if err := validateNamespace(namespace); err != nil {
+		return "", err
+	}
+	return namespace, nil
+}
+
+func validateNamespace(namespace string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+	}
+	return nil
 }
 
 func readInClusterNamespace() string {
@@ -187,6 +446,53 @@ func findBenchmarkConfig(evaluation *api.EvaluationJobResource, benchmarkID stri
 	return nil, fmt.Errorf("benchmark config not found for %q", benchmarkID)
 }
 
+// resolveRetryAttempts returns the backoff limit of the benchmark Job. The retry_attempts
+// of the evaluation job wins over the provider backoff_limit, and no retries are made
+// when neither is set.
+func resolveRetryAttempts(requested *int, providerBackoffLimit *int32) (int, error) {
+	if requested != nil {
+		if *requested < 0 {
+			return 0, fmt.Errorf("retry attempts cannot be negative")
+		}
+		return *requested, nil
+	}
+	if providerBackoffLimit != nil {
+		if *providerBackoffLimit < 0 {
+			return 0, fmt.Errorf("backoff limit cannot be negative")
+		}
+		return int(*providerBackoffLimit), nil
+	}
+	return 0, nil
+}
+
+// resolveTimeoutSeconds returns the timeout of the benchmark in seconds. The timeout_minutes
+// of the evaluation job wins over the provider timeout; nil means the benchmark has no deadline.
+func resolveTimeoutSeconds(requestedMinutes *int, providerTimeout time.Duration) (*int, error) {
+	if requestedMinutes != nil {
+		if *requestedMinutes < 0 {
+			return nil, fmt.Errorf("timeout minutes cannot be negative")
+		}
+		if *requestedMinutes > 0 {
+			return timeoutSecondsFromMinutes(requestedMinutes), nil
+		}
+	}
+	if providerTimeout < 0 {
+		return nil, fmt.Errorf("timeout cannot be negative")
+	}
+	if providerTimeout == 0 {
+		return nil, nil
+	}
+	seconds := int(math.Ceil(providerTimeout.Seconds()))
+	return &seconds, nil
+}
+
+func activeDeadlineFromSeconds(seconds *int) *int64 {
+	if seconds == nil {
+		return nil
+	}
+	return int64Ptr(int64(*seconds))
+}
+
 func timeoutSecondsFromMinutes(minutes *int) *int {
 	if minutes == nil {
 		return nil
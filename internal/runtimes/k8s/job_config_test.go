@@ -2,9 +2,12 @@ package k8s
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestBuildJobConfigDefaults(t *testing.T) {
@@ -221,6 +224,362 @@ func TestBuildJobConfigMissingBenchmarkConfig(t *testing.T) {
 	}
 }
 
+func TestBuildJobConfigUsesConfiguredNamespace(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+	provider.Runtime.K8s.Namespace = "eval-jobs"
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	if cfg.namespace != "eval-jobs" {
+		t.Fatalf("expected namespace %q, got %q", "eval-jobs", cfg.namespace)
+	}
+}
+
+func TestBuildJobConfigRejectsInvalidNamespace(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+	provider.Runtime.K8s.Namespace = "Not_A_Namespace"
+
+	if _, err := buildJobConfig(evaluation, &provider, "bench-1"); err == nil {
+		t.Fatalf("expected error for invalid namespace")
+	}
+}
+
+func TestResolveNamespace(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+		wantErr    bool
+	}{
+		{"default", "", defaultNamespace, false},
+		{"configured", "team-a", "team-a", false},
+		{"trimmed", " team-a ", "team-a", false},
+		{"uppercase", "Team-A", "", true},
+		{"too long", strings.Repeat("a", 64), "", true},
+		{"leading dash", "-team", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveNamespace(tt.configured)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for namespace %q", tt.configured)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected namespace %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildJobConfigProviderDeadlineAndBackoff(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+	backoff := int32(3)
+	provider.Runtime.K8s.Timeout = 90 * time.Minute
+	provider.Runtime.K8s.BackoffLimit = &backoff
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	if cfg.activeDeadline == nil || *cfg.activeDeadline != 5400 {
+		t.Fatalf("expected active deadline 5400, got %v", cfg.activeDeadline)
+	}
+	if cfg.retryAttempts != 3 {
+		t.Fatalf("expected retry attempts 3, got %d", cfg.retryAttempts)
+	}
+}
+
+func TestBuildJobConfigImagePull(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if job.Spec.Template.Spec.Containers[0].ImagePullPolicy != corev1.PullAlways || job.Spec.Template.Spec.ImagePullSecrets != nil {
+		t.Fatalf("expected the default pull policy without secrets, got %q %v",
+			job.Spec.Template.Spec.Containers[0].ImagePullPolicy, job.Spec.Template.Spec.ImagePullSecrets)
+	}
+
+	provider.Runtime.K8s.ImagePullPolicy = "IfNotPresent"
+	provider.Runtime.K8s.ImagePullSecrets = []string{"registry-credentials"}
+	cfg, err = buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	secrets := job.Spec.Template.Spec.ImagePullSecrets
+	if job.Spec.Template.Spec.Containers[0].ImagePullPolicy != corev1.PullIfNotPresent || len(secrets) != 1 || secrets[0].Name != "registry-credentials" {
+		t.Fatalf("unexpected pull policy %q and secrets %v", job.Spec.Template.Spec.Containers[0].ImagePullPolicy, secrets)
+	}
+
+	provider.Runtime.K8s.ImagePullPolicy = "Sometimes"
+	if _, err := buildJobConfig(evaluation, &provider, "bench-1"); err == nil || !strings.Contains(err.Error(), `image pull policy "Sometimes"`) {
+		t.Fatalf("expected an invalid pull policy error, got %v", err)
+	}
+}
+
+func TestValidateProviderConfigsImagePull(t *testing.T) {
+	provider := sampleProviders("provider-1")["provider-1"]
+	provider.Runtime.K8s.ImagePullPolicy = "IfNotPresent"
+	provider.Runtime.K8s.ImagePullSecrets = []string{"registry-credentials", " "}
+	if err := validateProviderConfigs(map[string]api.ProviderResource{"provider-1": provider}); err != nil {
+		t.Fatalf("expected a valid image pull config, got %v", err)
+	}
+
+	provider.Runtime.K8s.ImagePullPolicy = "Sometimes"
+	provider.Runtime.K8s.ImagePullSecrets = []string{"Registry_Credentials"}
+	err := validateProviderConfigs(map[string]api.ProviderResource{"provider-1": provider})
+	if err == nil {
+		t.Fatalf("expected an invalid image pull config to be rejected")
+	}
+	for _, expected := range []string{`image pull policy "Sometimes"`, `image pull secret "Registry_Credentials"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected %q in %v", expected, err)
+		}
+	}
+}
+
+func TestBuildJobConfigConfigMountPathAndFileName(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+
+	specMount := func(cfg *jobConfig) corev1.VolumeMount {
+		job, err := buildJob(cfg)
+		if err != nil {
+			t.Fatalf("buildJob returned error: %v", err)
+		}
+		for _, mount := range job.Spec.Template.Spec.Containers[0].VolumeMounts {
+			if mount.Name == jobSpecVolumeName {
+				return mount
+			}
+		}
+		t.Fatalf("expected the job spec to be mounted")
+		return corev1.VolumeMount{}
+	}
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	if mount := specMount(cfg); mount.MountPath != "/meta/job.json" || mount.SubPath != "job.json" {
+		t.Fatalf("expected the default mount, got %+v", mount)
+	}
+
+	provider.Runtime.K8s.ConfigMountPath = "/etc/adapter/"
+	provider.Runtime.K8s.ConfigFileName = "config.json"
+	cfg, err = buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	mount := specMount(cfg)
+	if mount.MountPath != "/etc/adapter/config.json" || mount.SubPath != "config.json" {
+		t.Fatalf("expected the configured mount, got %+v", mount)
+	}
+	if _, ok := buildConfigMap(cfg).Data[mount.SubPath]; !ok {
+		t.Fatalf("expected the configmap key to match the mounted file, got %v", buildConfigMap(cfg).Data)
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if adapter := job.Spec.Template.Spec.Containers[0]; adapter.TerminationMessagePath != "/etc/adapter/results.json" {
+		t.Fatalf("expected the results file next to the job spec, got %q", adapter.TerminationMessagePath)
+	}
+
+	provider.Runtime.K8s.ConfigFileName = api.BenchmarkResultsFile
+	if _, err := buildJobConfig(evaluation, &provider, "bench-1"); err == nil || !strings.Contains(err.Error(), "collides with /etc/adapter/results.json") {
+		t.Fatalf("expected a collision with the results file, got %v", err)
+	}
+
+	provider.Runtime.K8s.ConfigMountPath = "etc/adapter"
+	provider.Runtime.K8s.ConfigFileName = "../config.json"
+	_, err = buildJobConfig(evaluation, &provider, "bench-1")
+	if err == nil || !strings.Contains(err.Error(), "must be absolute") || !strings.Contains(err.Error(), "invalid config_file_name") {
+		t.Fatalf("expected both config file errors, got %v", err)
+	}
+
+	provider.Runtime.K8s.ConfigMountPath = "/data"
+	provider.Runtime.K8s.ConfigFileName = ""
+	if _, err := buildJobConfig(evaluation, &provider, "bench-1"); err == nil || !strings.Contains(err.Error(), "collides with /data") {
+		t.Fatalf("expected a collision with the data volume, got %v", err)
+	}
+}
+
+func TestBuildJobConfigPriorityClassAndRestartPolicy(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	podSpec := job.Spec.Template.Spec
+	if podSpec.RestartPolicy != corev1.RestartPolicyNever || podSpec.PriorityClassName != "" {
+		t.Fatalf("expected the default restart policy without priority class, got %q %q", podSpec.RestartPolicy, podSpec.PriorityClassName)
+	}
+
+	provider.Runtime.K8s.PriorityClassName = "batch-low"
+	provider.Runtime.K8s.RestartPolicy = "OnFailure"
+	cfg, err = buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	podSpec = job.Spec.Template.Spec
+	if podSpec.RestartPolicy != corev1.RestartPolicyOnFailure || podSpec.PriorityClassName != "batch-low" {
+		t.Fatalf("unexpected restart policy %q and priority class %q", podSpec.RestartPolicy, podSpec.PriorityClassName)
+	}
+
+	provider.Runtime.K8s.RestartPolicy = "Always"
+	if _, err := buildJobConfig(evaluation, &provider, "bench-1"); err == nil || !strings.Contains(err.Error(), `restart policy "Always"`) {
+		t.Fatalf("expected an invalid restart policy error, got %v", err)
+	}
+	provider.Runtime.K8s.RestartPolicy = ""
+	provider.Runtime.K8s.PriorityClassName = "Batch_Low"
+	if _, err := buildJobConfig(evaluation, &provider, "bench-1"); err == nil || !strings.Contains(err.Error(), "invalid priority class name") {
+		t.Fatalf("expected an invalid priority class error, got %v", err)
+	}
+}
+
+func TestBuildJobConfigTTLSecondsAfterFinished(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if ttl := job.Spec.TTLSecondsAfterFinished; ttl == nil || *ttl != defaultJobTTLSeconds {
+		t.Fatalf("expected the default ttl, got %v", ttl)
+	}
+
+	ttl := int32(600)
+	provider.Runtime.K8s.TTLSecondsAfterFinished = &ttl
+	cfg, err = buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	job, err = buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if got := job.Spec.TTLSecondsAfterFinished; got == nil || *got != 600 {
+		t.Fatalf("expected ttl 600, got %v", got)
+	}
+
+	negative := int32(-1)
+	provider.Runtime.K8s.TTLSecondsAfterFinished = &negative
+	err = validateProviderConfigs(map[string]api.ProviderResource{"provider-1": provider})
+	if err == nil || !strings.Contains(err.Error(), "ttl_seconds_after_finished") {
+		t.Fatalf("expected a negative ttl error, got %v", err)
+	}
+}
+
+func TestBuildJobConfigJobOverridesProviderDeadlineAndBackoff(t *testing.T) {
+	t.Setenv(serviceURLEnv, "http://eval-hub")
+	evaluation := sampleEvaluation("provider-1")
+	timeoutMinutes := 10
+	retry := 1
+	evaluation.TimeoutMinutes = &timeoutMinutes
+	evaluation.RetryAttempts = &retry
+	provider := sampleProviders("provider-1")["provider-1"]
+	backoff := int32(3)
+	provider.Runtime.K8s.Timeout = 2 * time.Hour
+	provider.Runtime.K8s.BackoffLimit = &backoff
+
+	cfg, err := buildJobConfig(evaluation, &provider, "bench-1")
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+	if cfg.activeDeadline == nil || *cfg.activeDeadline != 600 {
+		t.Fatalf("expected active deadline 600, got %v", cfg.activeDeadline)
+	}
+	if cfg.retryAttempts != 1 {
+		t.Fatalf("expected retry attempts 1, got %d", cfg.retryAttempts)
+	}
+}
+
+func TestResolveTimeoutSeconds(t *testing.T) {
+	zero, ten, negative := 0, 10, -1
+	tests := []struct {
+		name     string
+		minutes  *int
+		provider time.Duration
+		want     *int
+		wantErr  bool
+	}{
+		{"unset", nil, 0, nil, false},
+		{"provider only", nil, 90 * time.Second, intPtr(90), false},
+		{"provider rounds up", nil, 1500 * time.Millisecond, intPtr(2), false},
+		{"job wins", &ten, time.Hour, intPtr(600), false},
+		{"zero job falls back", &zero, time.Minute, intPtr(60), false},
+		{"negative job", &negative, 0, nil, true},
+		{"negative provider", nil, -time.Second, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTimeoutSeconds(tt.minutes, tt.provider)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveRetryAttemptsRejectsNegativeBackoff(t *testing.T) {
+	backoff := int32(-1)
+	if _, err := resolveRetryAttempts(nil, &backoff); err == nil {
+		t.Fatalf("expected error for negative backoff limit")
+	}
+}
+
 func TestNumExamplesFromParametersTypes(t *testing.T) {
 	tests := []struct {
 		name       string
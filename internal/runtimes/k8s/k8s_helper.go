@@ -4,10 +4,15 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -91,6 +96,152 @@ func (h *KubernetesHelper) DeleteConfigMap(ctx context.Context, namespace, name
 	return h.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// ListJobs returns the Jobs in the given namespace that match the label selector.
+func (h *KubernetesHelper) ListJobs(ctx context.Context, namespace, labelSelector string) ([]batchv1.Job, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	list, err := h.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// Ping verifies that the API server can be reached by listing at most one Job of the
+// namespace, the cheapest call that the service is allowed to make.
+func (h *KubernetesHelper) Ping(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	_, err := h.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// DeleteJob deletes a Job in the given namespace together with its pods.
+func (h *KubernetesHelper) DeleteJob(ctx context.Context, namespace, name string) error {
+	if namespace == "" || name == "" {
+		return fmt.Errorf("namespace and name are required")
+	}
+	propagation := metav1.DeletePropagationBackground
+	return h.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+}
+
+// ListConfigMaps returns the ConfigMaps in the given namespace that match the label selector.
+func (h *KubernetesHelper) ListConfigMaps(ctx context.Context, namespace, labelSelector string) ([]corev1.ConfigMap, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	list, err := h.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ListPods returns the pods in the given namespace that match the label selector.
+func (h *KubernetesHelper) ListPods(ctx context.Context, namespace, labelSelector string) ([]corev1.Pod, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	list, err := h.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// StreamPodLogs streams the logs of the most recent pod that matches the label selector, a
+// NotFound error is returned when there is no such pod yet.
+func (h *KubernetesHelper) StreamPodLogs(ctx context.Context, namespace, labelSelector string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	if namespace == "" || labelSelector == "" {
+		return nil, fmt.Errorf("namespace and label selector are required")
+	}
+	pods, err := h.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	var latest *corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if latest == nil || latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest = pod
+		}
+	}
+	if latest == nil {
+		return nil, apierrors.NewNotFound(corev1.Resource("pods"), labelSelector)
+	}
+	return h.clientset.CoreV1().Pods(namespace).GetLogs(latest.Name, opts).Stream(ctx)
+}
+
+// WaitForJobsFinished blocks until every Job that matches the label selector has completed
+// or failed, or no longer exists. The watch is started again every resync interval, and when
+// the API server closes it, so that a missed event can not block the caller forever.
+func (h *KubernetesHelper) WaitForJobsFinished(ctx context.Context, namespace, labelSelector string, resync time.Duration) error {
+	if namespace == "" || labelSelector == "" {
+		return fmt.Errorf("namespace and label selector are required")
+	}
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return err
+	}
+	jobs := h.clientset.BatchV1().Jobs(namespace)
+	for {
+		list, err := jobs.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return err
+		}
+		running := false
+		for i := range list.Items {
+			if !isJobFinished(&list.Items[i]) {
+				running = true
+				break
+			}
+		}
+		if !running {
+			return nil
+		}
+		if err := h.watchJobs(ctx, namespace, selector, list.ResourceVersion, resync); err != nil {
+			return err
+		}
+	}
+}
+
+// watchJobs returns once one of the selected Jobs finished or was deleted, or when the watch
+// ended before that.
+func (h *KubernetesHelper) watchJobs(ctx context.Context, namespace string, selector labels.Selector, resourceVersion string, resync time.Duration) error {
+	watcher, err := h.clientset.BatchV1().Jobs(namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector:   selector.String(),
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	timer := time.NewTimer(resync)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			job, isJob := event.Object.(*batchv1.Job)
+			if !isJob || !selector.Matches(labels.Set(job.Labels)) {
+				continue
+			}
+			if event.Type == watch.Deleted || isJobFinished(job) {
+				return nil
+			}
+		}
+	}
+}
+
 // SetConfigMapOwner sets a single owner reference on the ConfigMap.
 func (h *KubernetesHelper) SetConfigMapOwner(ctx context.Context, namespace, name string, owner metav1.OwnerReference) error {
 	if namespace == "" || name == "" {
@@ -3,113 +3,486 @@ package k8s
 // Runtime entrypoints for Kubernetes job creation.
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"path"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/config"
 	"github.com/eval-hub/eval-hub/internal/constants"
 	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const maxBenchmarkWorkers = 5
 
+// jobWatchResync is how often the watch of a running benchmark Job is started again.
+var jobWatchResync = 5 * time.Minute
+
 type K8sRuntime struct {
-	logger    *slog.Logger
-	helper    *KubernetesHelper
-	providers map[string]api.ProviderResource
-	ctx       context.Context
+	logger      *slog.Logger
+	helper      *KubernetesHelper
+	providers   map[string]api.ProviderResource
+	ctx         context.Context
+	submissions *submissionTracker
+	// artifacts is where the benchmark Jobs upload their outputs, nil when they are not uploaded
+	artifacts *api.ArtifactSink
 }
 
-// NewK8sRuntime creates a Kubernetes runtime.
-func NewK8sRuntime(logger *slog.Logger, providerConfigs map[string]api.ProviderResource) (abstractions.Runtime, error) {
+// NewK8sRuntime creates a Kubernetes runtime, the artifact sink and the cleanup config are
+// optional. The orphaned ConfigMap reconciler is started when the cleanup is enabled.
+func NewK8sRuntime(logger *slog.Logger, providerConfigs map[string]api.ProviderResource, artifacts *api.ArtifactSink, cleanup *config.CleanupConfig) (abstractions.Runtime, error) {
+	if err := validateProviderConfigs(providerConfigs); err != nil {
+		return nil, err
+	}
+	if err := validateArtifactSink(artifacts); err != nil {
+		return nil, err
+	}
 	helper, err := NewKubernetesHelper()
 	if err != nil {
 		return nil, err
 	}
-	return &K8sRuntime{logger: logger, helper: helper, providers: providerConfigs}, nil
+	runtime := &K8sRuntime{
+		logger:      logger,
+		helper:      helper,
+		providers:   providerConfigs,
+		submissions: newSubmissionTracker(),
+		artifacts:   artifacts,
+	}
+	if reconciler := newConfigMapReconciler(logger, helper, runtime.namespaces(), cleanup); reconciler != nil {
+		go reconciler.run(runtime.submissions.watching)
+	}
+	return runtime, nil
 }
 
 func (r *K8sRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
 	return &K8sRuntime{
-		logger:    logger,
-		helper:    r.helper,
-		providers: r.providers,
-		ctx:       r.ctx,
+		logger:      logger,
+		helper:      r.helper,
+		providers:   r.providers,
+		ctx:         r.ctx,
+		submissions: r.submissions,
+		artifacts:   r.artifacts,
 	}
 }
 
 func (r *K8sRuntime) WithContext(ctx context.Context) abstractions.Runtime {
 	return &K8sRuntime{
-		logger:    r.logger,
-		helper:    r.helper,
-		providers: r.providers,
-		ctx:       ctx,
+		logger:      r.logger,
+		helper:      r.helper,
+		providers:   r.providers,
+		ctx:         ctx,
+		submissions: r.submissions,
+		artifacts:   r.artifacts,
+	}
+}
+
+// validateProviderConfigs checks the provider runtime settings that can be verified
+// at startup so that a misconfigured provider is rejected before any job is submitted.
+func validateProviderConfigs(providerConfigs map[string]api.ProviderResource) error {
+	var errs []error
+	for id, provider := range providerConfigs {
+		if provider.Runtime == nil || provider.Runtime.K8s == nil {
+			continue
+		}
+		if _, err := resolveNamespace(provider.Runtime.K8s.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		if provider.Runtime.K8s.MaxConcurrentBenchmarks < 0 {
+			errs = append(errs, fmt.Errorf("provider %s: max_concurrent_benchmarks must not be negative", id))
+		}
+		if ttl := provider.Runtime.K8s.TTLSecondsAfterFinished; ttl != nil && *ttl < 0 {
+			errs = append(errs, fmt.Errorf("provider %s: ttl_seconds_after_finished must not be negative", id))
+		}
+		if _, err := resolveImagePullPolicy(provider.Runtime.K8s.ImagePullPolicy); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		if err := validateImagePullSecrets(provider.Runtime.K8s.ImagePullSecrets); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		if err := validateResourceMetadata(provider.Runtime.K8s.Labels, provider.Runtime.K8s.Annotations); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		configMountPath, configFileName, err := resolveConfigFile(provider.Runtime.K8s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		configFile := path.Join(configMountPath, configFileName)
+		if err := validateInitContainer(provider.Runtime.K8s.InitContainer, configFile); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		if err := validateScratchVolume(provider.Runtime.K8s.ScratchVolume, provider.Runtime.K8s.InitContainer, configFile); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+		if _, err := resolveRestartPolicy(provider.Runtime.K8s.RestartPolicy); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateBenchmarks builds the configuration of every benchmark before anything is
+// created in the cluster, so all configuration errors of the job are reported together.
+func (r *K8sRuntime) validateBenchmarks(evaluation *api.EvaluationJobResource) error {
+	var errs []error
+	for i := range evaluation.Benchmarks {
+		benchmark := &evaluation.Benchmarks[i]
+		resources, err := r.buildBenchmarkResources(evaluation, benchmark)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("benchmark %s: %w", benchmark.ID, err))
+			continue
+		}
+		if err := validateConfigMapSize(resources.jobConfig); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
+// RunEvaluationJob submits the benchmarks of the job. The benchmarks of a provider with
+// max_concurrent_benchmarks are submitted as its earlier benchmark Jobs finish, the others
+// are all submitted at once. RunEvaluationJob returns once the benchmarks that can start
+// right away were submitted, with a BenchmarkError for each of them that failed. Such a
+// failure does not stop the other benchmarks, it is recorded on the benchmark in the storage.
+// Once a benchmark Job finished its scores are collected. The submissions outlive the request
+// that started them and are stopped by CancelEvaluationJob.
 func (r *K8sRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, storage *abstractions.Storage) error {
-	benchmarks := make(chan api.BenchmarkConfig, len(evaluation.Benchmarks))
+	if err := r.validateBenchmarks(evaluation); err != nil {
+		return fmt.Errorf("job %s: %w", evaluation.Resource.ID, err)
+	}
+
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, watchCtx, done, err := r.submissions.start(context.WithoutCancel(ctx), evaluation.Resource.ID)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", evaluation.Resource.ID, err)
+	}
+	storage = detachStorage(ctx, storage)
+
+	limited := map[string][]api.BenchmarkConfig{}
+	var unlimited []api.BenchmarkConfig
 	for _, bench := range evaluation.Benchmarks {
-		benchmarks <- bench
+		if r.maxConcurrentBenchmarks(bench.ProviderID) > 0 {
+			limited[bench.ProviderID] = append(limited[bench.ProviderID], bench)
+		} else {
+			unlimited = append(unlimited, bench)
+		}
 	}
-	close(benchmarks)
 
-	workerCount := maxBenchmarkWorkers
-	if len(evaluation.Benchmarks) < workerCount {
-		workerCount = len(evaluation.Benchmarks)
+	// started is done once the benchmarks that do not wait for a free slot were submitted
+	var wg, started sync.WaitGroup
+	failures := &submissionErrors{}
+	benchmarks := make(chan api.BenchmarkConfig, len(unlimited))
+	for _, bench := range unlimited {
+		benchmarks <- bench
 	}
+	close(benchmarks)
 
+	workerCount := min(maxBenchmarkWorkers, len(unlimited))
 	for i := 0; i < workerCount; i++ {
-		go func() {
+		started.Add(1)
+		wg.Go(func() {
+			defer started.Done()
 			for bench := range benchmarks {
-				select {
-				case <-r.ctx.Done():
-					r.logger.Warn(
-						"benchmark processing canceled",
-						"job_id", evaluation.Resource.ID,
-						"benchmark_id", bench.ID,
-					)
+				if r.submissionCanceled(ctx, evaluation, &bench) {
 					return
-				default:
 				}
-				if err := r.createBenchmarkResources(r.ctx, r.logger, evaluation, &bench); err != nil {
-					r.logger.Error(
-						"kubernetes job creation failed",
-						"error", err,
-						"job_id", evaluation.Resource.ID,
-						"benchmark_id", bench.ID,
-					)
-
-					if storage != nil && *storage != nil {
-						runStatus := buildBenchmarkFailureStatus(&bench, err)
-						if updateErr := (*storage).UpdateEvaluationJob(evaluation.Resource.ID, runStatus); updateErr != nil {
-							r.logger.Error(
-								"failed to update benchmark status",
-								"error", updateErr,
-								"job_id", evaluation.Resource.ID,
-								"benchmark_id", bench.ID,
-							)
-						}
-					}
+				if r.benchmarkCanceled(evaluation, &bench) {
+					continue
+				}
+				if err := r.submitBenchmark(ctx, evaluation, storage, &bench); err != nil {
+					failures.add(err)
+					continue
 				}
+				wg.Go(func() {
+					r.awaitBenchmark(watchCtx, evaluation, storage, &bench)
+				})
 			}
-		}()
+		})
+	}
+	for providerID, providerBenchmarks := range limited {
+		started.Add(1)
+		wg.Go(func() {
+			r.submitBenchmarksWithLimit(ctx, watchCtx, evaluation, storage, providerBenchmarks, r.maxConcurrentBenchmarks(providerID), sync.OnceFunc(started.Done), failures)
+		})
 	}
+	go func() {
+		wg.Wait()
+		done()
+	}()
 
-	return nil
+	started.Wait()
+	return failures.join()
+}
+
+// submissionErrors collects the errors of the benchmarks that failed to be submitted while
+// RunEvaluationJob waits for them.
+type submissionErrors struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (e *submissionErrors) add(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errs = append(e.errs, err)
+}
+
+func (e *submissionErrors) join() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return errors.Join(e.errs...)
+}
+
+// detachStorage returns the storage bound to the context of the submissions, the storage of
+// the request that started them is done once the response is written.
+func detachStorage(ctx context.Context, storage *abstractions.Storage) *abstractions.Storage {
+	if storage == nil || *storage == nil {
+		return storage
+	}
+	detached := (*storage).WithContext(ctx)
+	return &detached
+}
+
+// submitBenchmarksWithLimit submits the benchmarks so that at most limit of their Jobs run
+// at the same time, a new Job is created when a running one finishes. The remaining
+// benchmarks are not submitted once the watchers stopped, as the limit can not be kept.
+// The failures of the first limit benchmarks are added to failures and started is called
+// once they were submitted, the later failures are only recorded in the storage.
+func (r *K8sRuntime) submitBenchmarksWithLimit(ctx context.Context, watchCtx context.Context, evaluation *api.EvaluationJobResource, storage *abstractions.Storage, benchmarks []api.BenchmarkConfig, limit int, started func(), failures *submissionErrors) {
+	slots := make(chan struct{}, limit)
+	var running sync.WaitGroup
+	defer running.Wait()
+	defer started()
+
+	for i := range benchmarks {
+		bench := &benchmarks[i]
+		if i == limit {
+			started()
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-watchCtx.Done():
+		}
+		if r.submissionCanceled(watchCtx, evaluation, bench) {
+			return
+		}
+		if r.benchmarkCanceled(evaluation, bench) {
+			<-slots
+			continue
+		}
+		if err := r.submitBenchmark(ctx, evaluation, storage, bench); err != nil {
+			if i < limit {
+				failures.add(err)
+			}
+			<-slots
+			continue
+		}
+		running.Go(func() {
+			defer func() { <-slots }()
+			r.awaitBenchmark(watchCtx, evaluation, storage, bench)
+		})
+	}
+}
+
+// submissionCanceled reports whether the submissions of the job were canceled before the
+// benchmark could be submitted.
+func (r *K8sRuntime) submissionCanceled(ctx context.Context, evaluation *api.EvaluationJobResource, bench *api.BenchmarkConfig) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	r.logger.Warn(
+		"benchmark processing canceled",
+		"job_id", evaluation.Resource.ID,
+		"benchmark_id", bench.ID,
+	)
+	return true
+}
+
+// benchmarkCanceled reports whether the benchmark was cancelled on its own before it could
+// be submitted.
+func (r *K8sRuntime) benchmarkCanceled(evaluation *api.EvaluationJobResource, bench *api.BenchmarkConfig) bool {
+	if !r.submissions.benchmarkCanceled(evaluation.Resource.ID, bench.ID) {
+		return false
+	}
+	r.logger.Info(
+		"benchmark canceled before submission",
+		"job_id", evaluation.Resource.ID,
+		"benchmark_id", bench.ID,
+	)
+	return true
+}
+
+// submitBenchmark creates the resources of the benchmark. A success records the benchmark
+// as pending and where it uploads its artifacts, a failure is recorded as the status of the
+// benchmark and returned as a BenchmarkError.
+func (r *K8sRuntime) submitBenchmark(ctx context.Context, evaluation *api.EvaluationJobResource, storage *abstractions.Storage, bench *api.BenchmarkConfig) error {
+	err := r.createBenchmarkResources(ctx, r.logger, evaluation, bench)
+	if err == nil {
+		r.recordSubmitted(evaluation, storage, bench)
+		r.recordArtifacts(evaluation, storage, bench)
+		return nil
+	}
+	r.logger.Error(
+		"kubernetes job creation failed",
+		"error", err,
+		"job_id", evaluation.Resource.ID,
+		"benchmark_id", bench.ID,
+	)
+
+	if storage != nil && *storage != nil {
+		runStatus := buildBenchmarkFailureStatus(bench, err)
+		if updateErr := (*storage).UpdateEvaluationJob(evaluation.Resource.ID, runStatus); updateErr != nil {
+			r.logger.Error(
+				"failed to update benchmark status",
+				"error", updateErr,
+				"job_id", evaluation.Resource.ID,
+				"benchmark_id", bench.ID,
+			)
+		}
+	}
+	return &abstractions.BenchmarkError{ProviderID: bench.ProviderID, BenchmarkID: bench.ID, Err: err}
+}
+
+// recordSubmitted adds the submitted benchmark to the statuses of the stored job as pending,
+// until its adapter reports that it is running.
+func (r *K8sRuntime) recordSubmitted(evaluation *api.EvaluationJobResource, storage *abstractions.Storage, bench *api.BenchmarkConfig) {
+	if storage == nil || *storage == nil {
+		return
+	}
+	runStatus := &api.StatusEvent{
+		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID: bench.ProviderID,
+			ID:         bench.ID,
+			Status:     api.StatePending,
+		},
+	}
+	if err := (*storage).UpdateEvaluationJob(evaluation.Resource.ID, runStatus); err != nil {
+		r.logger.Error(
+			"failed to update benchmark status",
+			"error", err,
+			"job_id", evaluation.Resource.ID,
+			"benchmark_id", bench.ID,
+		)
+	}
+}
+
+// recordArtifacts attaches the artifact URI of the submitted benchmark to the stored job.
+func (r *K8sRuntime) recordArtifacts(evaluation *api.EvaluationJobResource, storage *abstractions.Storage, bench *api.BenchmarkConfig) {
+	if r.artifacts == nil || storage == nil || *storage == nil {
+		return
+	}
+	artifact := api.ArtifactReference{
+		BenchmarkID: bench.ID,
+		ProviderID:  bench.ProviderID,
+		URI:         r.artifacts.BenchmarkURI(evaluation.Resource.ID, bench.ID),
+	}
+	if err := (*storage).AddEvaluationJobArtifacts(evaluation.Resource.ID, []api.ArtifactReference{artifact}); err != nil {
+		r.logger.Error(
+			"failed to record benchmark artifacts",
+			"error", err,
+			"job_id", evaluation.Resource.ID,
+			"benchmark_id", bench.ID,
+		)
+	}
+}
+
+func (r *K8sRuntime) maxConcurrentBenchmarks(providerID string) int {
+	provider, ok := r.providers[providerID]
+	if !ok || provider.Runtime == nil || provider.Runtime.K8s == nil {
+		return 0
+	}
+	return provider.Runtime.K8s.MaxConcurrentBenchmarks
+}
+
+// benchmarkResources are the Kubernetes objects created for a benchmark.
+type benchmarkResources struct {
+	jobConfig *jobConfig
+	configMap *corev1.ConfigMap
+	job       *batchv1.Job
+}
+
+// buildBenchmarkResources builds the objects of the benchmark without creating them, the
+// submission and the dry run both use it so that they fail on the same configuration errors.
+func (r *K8sRuntime) buildBenchmarkResources(evaluation *api.EvaluationJobResource, benchmark *api.BenchmarkConfig) (*benchmarkResources, error) {
+	provider := r.providers[benchmark.ProviderID]
+	jobConfig, err := buildJobConfig(evaluation, &provider, benchmark.ID)
+	if err != nil {
+		return nil, err
+	}
+	jobConfig.artifacts = r.artifacts
+	job, err := buildJob(jobConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &benchmarkResources{
+		jobConfig: jobConfig,
+		configMap: buildConfigMap(jobConfig),
+		job:       job,
+	}, nil
+}
+
+// RenderEvaluationJob returns the ConfigMap and the Job of every benchmark as RunEvaluationJob
+// would create them, after the same validation.
+func (r *K8sRuntime) RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error) {
+	if err := r.validateBenchmarks(evaluation); err != nil {
+		return nil, fmt.Errorf("job %s: %w", evaluation.Resource.ID, err)
+	}
+	var rendered []api.RenderedResource
+	for i := range evaluation.Benchmarks {
+		benchmark := &evaluation.Benchmarks[i]
+		resources, err := r.buildBenchmarkResources(evaluation, benchmark)
+		if err != nil {
+			return nil, fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmark.ID, err)
+		}
+		configMap, err := renderResource(resources.configMap.Kind, resources.configMap.Name, benchmark, resources.configMap)
+		if err != nil {
+			return nil, fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmark.ID, err)
+		}
+		job, err := renderResource(resources.job.Kind, resources.job.Name, benchmark, resources.job)
+		if err != nil {
+			return nil, fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmark.ID, err)
+		}
+		rendered = append(rendered, *configMap, *job)
+	}
+	return rendered, nil
+}
+
+func renderResource(kind string, name string, benchmark *api.BenchmarkConfig, object any) (*api.RenderedResource, error) {
+	manifest, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("render %s %s: %w", kind, name, err)
+	}
+	return &api.RenderedResource{
+		Kind:        kind,
+		Name:        name,
+		ProviderID:  benchmark.ProviderID,
+		BenchmarkID: benchmark.ID,
+		Manifest:    manifest,
+	}, nil
 }
 
 func (r *K8sRuntime) createBenchmarkResources(ctx context.Context, logger *slog.Logger, evaluation *api.EvaluationJobResource, benchmark *api.BenchmarkConfig) error {
 	benchmarkID := benchmark.ID
 	// Provider/benchmark validation should be handled during creation.
-	provider := r.providers[benchmark.ProviderID]
-	jobConfig, err := buildJobConfig(evaluation, &provider, benchmarkID)
+	resources, err := r.buildBenchmarkResources(evaluation, benchmark)
 	if err != nil {
-		logger.Error("kubernetes job config error", "benchmark_id", benchmarkID, "error", err)
+		logger.Error("kubernetes job build error", "benchmark_id", benchmarkID, "error", err)
 		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
 	}
+	jobConfig, configMap, job := resources.jobConfig, resources.configMap, resources.job
 	logger.Info(
 		"kubernetes job config",
 		"job_id", evaluation.Resource.ID,
@@ -118,12 +491,6 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context, logger *slog.
 		"service_ca_configmap", jobConfig.serviceCAConfigMap,
 		"evalhub_url", jobConfig.evalHubURL,
 	)
-	configMap := buildConfigMap(jobConfig)
-	job, err := buildJob(jobConfig)
-	if err != nil {
-		logger.Error("kubernetes job build error", "benchmark_id", benchmarkID, "error", err)
-		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
-	}
 	hasServiceCAVolume := false
 	for _, volume := range job.Spec.Template.Spec.Volumes {
 		if volume.Name == serviceCAVolumeName {
@@ -152,6 +519,12 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context, logger *slog.
 	logger.Info("kubernetes resource", "kind", "ConfigMap", "object", configMap)
 	logger.Info("kubernetes resource", "kind", "Job", "object", job)
 
+	// A benchmark that is retried reuses the names of its earlier Job and ConfigMap.
+	if err := r.deleteResources(ctx, job.Namespace, benchmarkSelector(evaluation.Resource.ID, benchmarkID)); err != nil {
+		logger.Error("kubernetes stale resource delete error", "benchmark_id", benchmarkID, "error", err)
+		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
+	}
+
 	_, err = r.helper.CreateConfigMap(ctx, configMap.Namespace, configMap.Name, configMap.Data, &CreateConfigMapOptions{
 		Labels:      configMap.Labels,
 		Annotations: configMap.Annotations,
@@ -185,6 +558,181 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context, logger *slog.
 	return nil
 }
 
+// Shutdown stops the watchers of the benchmark Jobs and the orphaned ConfigMap reconciler,
+// and waits for the benchmark submissions in progress until the context is done. The Jobs
+// keep running in the cluster.
+func (r *K8sRuntime) Shutdown(ctx context.Context) error {
+	return r.submissions.shutdown(ctx)
+}
+
+// CancelEvaluationJob stops the pending benchmark submissions of the job and deletes the
+// Jobs and ConfigMaps labelled with the job ID in every namespace the providers submit to.
+// The ConfigMaps are normally garbage collected with their Job, they are deleted explicitly
+// for the ones whose owner was never set.
+func (r *K8sRuntime) CancelEvaluationJob(jobID string) error {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if r.submissions.cancel(jobID) {
+		r.logger.Info("pending benchmark submissions canceled", "job_id", jobID)
+	}
+	selector := labels.SelectorFromSet(labels.Set{labelJobIDKey: jobID}).String()
+	var errs []error
+	for _, namespace := range r.namespaces() {
+		if err := r.deleteResources(ctx, namespace, selector); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CancelBenchmark deletes the Job and the ConfigMap of the benchmark in every namespace the
+// providers submit to, the other benchmarks of the job keep running. A benchmark that is
+// still waiting for a free slot of its provider is not submitted anymore. Deleting the Job
+// ends the watch of the benchmark, which frees its slot.
+func (r *K8sRuntime) CancelBenchmark(jobID string, benchmarkID string) error {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	r.submissions.cancelBenchmark(jobID, benchmarkID)
+	selector := benchmarkSelector(jobID, benchmarkID)
+	var errs []error
+	for _, namespace := range r.namespaces() {
+		if err := r.deleteResources(ctx, namespace, selector); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deleteResources deletes the Jobs and the ConfigMaps in the namespace that match the label
+// selector.
+func (r *K8sRuntime) deleteResources(ctx context.Context, namespace string, selector string) error {
+	jobs, err := r.helper.ListJobs(ctx, namespace, selector)
+	if err != nil {
+		return fmt.Errorf("list jobs in namespace %s: %w", namespace, err)
+	}
+	var errs []error
+	for _, job := range jobs {
+		if err := r.helper.DeleteJob(ctx, namespace, job.Name); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("delete job %s/%s: %w", namespace, job.Name, err))
+			continue
+		}
+		r.logger.Info("kubernetes job deleted", "job_id", job.Labels[labelJobIDKey], "namespace", namespace, "name", job.Name)
+	}
+	configMaps, err := r.helper.ListConfigMaps(ctx, namespace, selector)
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list configmaps in namespace %s: %w", namespace, err))...)
+	}
+	for _, configMap := range configMaps {
+		if err := r.helper.DeleteConfigMap(ctx, namespace, configMap.Name); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("delete configmap %s/%s: %w", namespace, configMap.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CountActiveJobs counts the evaluation Jobs that have not completed or failed yet in
+// every namespace the providers submit to.
+func (r *K8sRuntime) CountActiveJobs() (int, error) {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		labelAppKey:       labelAppValue,
+		labelComponentKey: labelComponentValue,
+	}).String()
+	count := 0
+	var errs []error
+	for _, namespace := range r.namespaces() {
+		jobs, err := r.helper.ListJobs(ctx, namespace, selector)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list jobs in namespace %s: %w", namespace, err))
+			continue
+		}
+		for i := range jobs {
+			if !isJobFinished(&jobs[i]) {
+				count++
+			}
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// StreamBenchmarkLogs streams the adapter container logs of the benchmark Job, looking for
+// its pod in every namespace the providers submit to.
+func (r *K8sRuntime) StreamBenchmarkLogs(jobID string, benchmarkID string, options abstractions.LogOptions) (io.ReadCloser, error) {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logOptions := &corev1.PodLogOptions{
+		Container: adapterContainerName,
+		Follow:    options.Follow,
+		TailLines: options.TailLines,
+	}
+	selector := benchmarkSelector(jobID, benchmarkID)
+	for _, namespace := range r.namespaces() {
+		logs, err := r.helper.StreamPodLogs(ctx, namespace, selector, logOptions)
+		if err == nil {
+			return logs, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("stream logs of job %s benchmark %s in namespace %s: %w", jobID, benchmarkID, namespace, err)
+		}
+	}
+	return nil, fmt.Errorf("job %s benchmark %s: %w", jobID, benchmarkID, abstractions.ErrBenchmarkNotFound)
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaces returns the distinct namespaces the providers create their resources in.
+func (r *K8sRuntime) namespaces() []string {
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, provider := range r.providers {
+		if provider.Runtime == nil || provider.Runtime.K8s == nil {
+			continue
+		}
+		namespace, err := resolveNamespace(provider.Runtime.K8s.Namespace)
+		if err != nil || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// ReadinessChecks checks that the Kubernetes API server can be reached.
+func (r *K8sRuntime) ReadinessChecks() []abstractions.ReadinessCheck {
+	return []abstractions.ReadinessCheck{{
+		Name: "kubernetes",
+		Check: func(ctx context.Context) error {
+			namespaces := r.namespaces()
+			if len(namespaces) == 0 {
+				namespace, err := resolveNamespace("")
+				if err != nil {
+					return err
+				}
+				namespaces = []string{namespace}
+			}
+			return r.helper.Ping(ctx, namespaces[0])
+		},
+	}}
+}
+
 func buildBenchmarkFailureStatus(benchmark *api.BenchmarkConfig, runErr error) *api.StatusEvent {
 	return &api.StatusEvent{
 		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
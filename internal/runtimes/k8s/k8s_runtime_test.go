@@ -33,9 +33,13 @@ func TestRunEvaluationJobCreatesResources(t *testing.T) {
 	jobID := "1936da05-2f27-4fd4-b000-ebcb71af1fbe"
 	benchmarkID := "arc_easy"
 	benchmarkIDTwo := "arc"
+	// the request context of the handler that runs the job
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
 	runtime := &K8sRuntime{
 		logger: logger,
 		helper: helper,
+		ctx:    ctx,
 		providers: map[string]api.ProviderResource{
 			"lm_evaluation_harness": {
 				ProviderID: "lm_evaluation_harness",
@@ -112,8 +116,8 @@ func TestRunEvaluationJobCreatesResources(t *testing.T) {
 		found := false
 		deadline := time.Now().Add(apiTimeout)
 		for time.Now().Before(deadline) {
-			if _, err := helper.clientset.CoreV1().ConfigMaps(namespace).Get(context.Background(), configMapName, metav1.GetOptions{}); err == nil {
-				if _, err := helper.clientset.BatchV1().Jobs(namespace).Get(context.Background(), jobName, metav1.GetOptions{}); err == nil {
+			if _, err := helper.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, configMapName, metav1.GetOptions{}); err == nil {
+				if _, err := helper.clientset.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{}); err == nil {
 					found = true
 					break
 				}
@@ -194,8 +198,7 @@ func TestCreateBenchmarkResourcesReturnsErrorWhenConfigMapExists(t *testing.T) {
 	}
 }
 
-func TestRunEvaluationJobReturnsNilOnCreateFailure(t *testing.T) {
-	// Unit test: RunEvaluationJob returns immediately; create failures happen in goroutines.
+func TestRunEvaluationJobReturnsBenchmarkErrorOnCreateFailure(t *testing.T) {
 	t.Setenv("SERVICE_URL", "http://eval-hub")
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	clientset := fake.NewSimpleClientset()
@@ -242,8 +245,12 @@ func TestRunEvaluationJobReturnsNilOnCreateFailure(t *testing.T) {
 	}
 
 	var storageNil = (*abstractions.Storage)(nil)
-	if err := runtime.RunEvaluationJob(evaluation, storageNil); err != nil {
-		t.Fatalf("expected nil error, got %v", err)
+	err := runtime.RunEvaluationJob(evaluation, storageNil)
+	if benchmarkErrs, ok := abstractions.BenchmarkErrors(err); !ok || len(benchmarkErrs) != 1 || benchmarkErrs[0].BenchmarkID != "bench-1" {
+		t.Fatalf("expected a benchmark error for bench-1, got %v", err)
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		t.Fatalf("expected the API error to be wrapped, got %v", err)
 	}
 
 	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0]); err == nil {
@@ -2,18 +2,29 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
 	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	k8stesting "k8s.io/client-go/testing"
 )
 
@@ -24,12 +35,31 @@ type fakeStorage struct {
 	runStatus     *api.StatusEvent
 	runStatusChan chan *api.StatusEvent
 	updateErr     error
+	artifacts     []api.ArtifactReference
+	// statuses records every update, it is shared by the copies of the storage
+	statuses *statusLog
+}
+
+type statusLog struct {
+	mu     sync.Mutex
+	events map[string]*api.BenchmarkStatusEvent
+}
+
+func (l *statusLog) get(benchmarkID string) *api.BenchmarkStatusEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.events[benchmarkID]
 }
 
 // UpdateEvaluationJob implements [abstractions.Storage].
 func (f *fakeStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error {
 	f.called = true
 	f.runStatus = runStatus
+	if f.statuses != nil {
+		f.statuses.mu.Lock()
+		f.statuses.events[runStatus.BenchmarkStatusEvent.ID] = runStatus.BenchmarkStatusEvent
+		f.statuses.mu.Unlock()
+	}
 	if f.runStatusChan != nil {
 		select {
 		case f.runStatusChan <- runStatus:
@@ -41,13 +71,16 @@ func (f *fakeStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 
 func (f *fakeStorage) GetDatasourceName() string  { return "fake" }
 func (f *fakeStorage) Ping(_ time.Duration) error { return nil }
-func (f *fakeStorage) CreateEvaluationJob(_ *api.EvaluationJobConfig, _ string) (*api.EvaluationJobResource, error) {
+func (f *fakeStorage) CreateEvaluationJob(_ *api.EvaluationJobConfig, _ string, _ string) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) FindEvaluationJobByIdempotencyKey(_ string) (*api.EvaluationJobResource, error) {
 	return nil, nil
 }
 func (f *fakeStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
 	return nil, nil
 }
-func (f *fakeStorage) GetEvaluationJobs(int, _ int, _ string) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+func (f *fakeStorage) GetEvaluationJobs(int, _ int, _ abstractions.EvaluationJobFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
 	return nil, nil
 }
 func (f *fakeStorage) DeleteEvaluationJob(_ string, _ bool) error {
@@ -57,6 +90,16 @@ func (f *fakeStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _
 	f.called = true
 	return nil
 }
+func (f *fakeStorage) ResetEvaluationJobBenchmarks(_ string, _ []string) error {
+	return nil
+}
+func (f *fakeStorage) AddEvaluationJobArtifacts(_ string, artifacts []api.ArtifactReference) error {
+	f.artifacts = append(f.artifacts, artifacts...)
+	return nil
+}
+func (f *fakeStorage) CountEvaluationJobsByStatus() (map[api.OverallState]int, error) {
+	return nil, nil
+}
 func (f *fakeStorage) CreateCollection(_ *api.CollectionResource) error {
 	return nil
 }
@@ -80,6 +123,7 @@ func (f *fakeStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
 		ctx:           f.ctx,
 		runStatusChan: f.runStatusChan,
 		updateErr:     f.updateErr,
+		statuses:      f.statuses,
 	}
 }
 func (f *fakeStorage) WithContext(ctx context.Context) abstractions.Storage {
@@ -88,6 +132,7 @@ func (f *fakeStorage) WithContext(ctx context.Context) abstractions.Storage {
 		ctx:           ctx,
 		runStatusChan: f.runStatusChan,
 		updateErr:     f.updateErr,
+		statuses:      f.statuses,
 	}
 }
 
@@ -135,6 +180,104 @@ func TestCreateBenchmarkResourcesSetsConfigMapOwner(t *testing.T) {
 	}
 }
 
+func TestRenderEvaluationJobReturnsManifestsWithoutCreating(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+
+	clientset := fake.NewSimpleClientset()
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: sampleProviders(providerID),
+		ctx:       context.Background(),
+	}
+
+	resources, err := runtime.RenderEvaluationJob(evaluation)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(clientset.Actions()) != 0 {
+		t.Fatalf("expected no calls to the cluster, got %v", clientset.Actions())
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected a configmap and a job, got %d resources", len(resources))
+	}
+
+	cmName := configMapName(evaluation.Resource.ID, evaluation.Benchmarks[0].ID)
+	if resources[0].Kind != "ConfigMap" || resources[0].Name != cmName || resources[0].BenchmarkID != "bench-1" {
+		t.Fatalf("unexpected configmap resource %+v", resources[0])
+	}
+	cm := &corev1.ConfigMap{}
+	if err := json.Unmarshal(resources[0].Manifest, cm); err != nil {
+		t.Fatalf("failed to decode the configmap manifest: %v", err)
+	}
+	if cm.Kind != "ConfigMap" || cm.APIVersion != "v1" || cm.Namespace != defaultNamespace || cm.Data[jobSpecFileName] == "" {
+		t.Fatalf("unexpected configmap manifest %+v", cm)
+	}
+
+	name := jobName(evaluation.Resource.ID, evaluation.Benchmarks[0].ID)
+	if resources[1].Kind != "Job" || resources[1].Name != name || resources[1].ProviderID != providerID {
+		t.Fatalf("unexpected job resource %+v", resources[1])
+	}
+	job := &batchv1.Job{}
+	if err := json.Unmarshal(resources[1].Manifest, job); err != nil {
+		t.Fatalf("failed to decode the job manifest: %v", err)
+	}
+	if job.Kind != "Job" || job.APIVersion != "batch/v1" || job.Name != name {
+		t.Fatalf("unexpected job manifest %s %s %s", job.APIVersion, job.Kind, job.Name)
+	}
+	containers := job.Spec.Template.Spec.Containers
+	if len(containers) != 1 || containers[0].Image != "quay.io/eval-hub/adapter:latest" {
+		t.Fatalf("unexpected job containers %+v", containers)
+	}
+}
+
+func TestRenderEvaluationJobValidatesLikeSubmission(t *testing.T) {
+	evaluation := sampleEvaluation("provider-1")
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: fake.NewSimpleClientset()},
+		providers: map[string]api.ProviderResource{},
+		ctx:       context.Background(),
+	}
+
+	if _, err := runtime.RenderEvaluationJob(evaluation); err == nil {
+		t.Fatalf("expected an error for a provider without a runtime")
+	}
+}
+
+func TestSubmitBenchmarkRecordsArtifacts(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+
+	clientset := fake.NewSimpleClientset()
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: sampleProviders(providerID),
+		ctx:       context.Background(),
+		artifacts: &api.ArtifactSink{Bucket: "eval-artifacts"},
+	}
+	recorder := &fakeStorage{}
+	var storage abstractions.Storage = recorder
+
+	if err := runtime.submitBenchmark(context.Background(), evaluation, &storage, &evaluation.Benchmarks[0]); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(recorder.artifacts) != 1 || recorder.artifacts[0].URI != "s3://eval-artifacts/job-1/bench-1/" || recorder.artifacts[0].ProviderID != providerID {
+		t.Fatalf("unexpected recorded artifacts %+v", recorder.artifacts)
+	}
+	job, err := clientset.BatchV1().Jobs(defaultNamespace).Get(context.Background(), jobName("job-1", "bench-1"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the job to be created, got %v", err)
+	}
+	if len(job.Spec.Template.Spec.InitContainers) != 1 {
+		t.Fatalf("expected the job to upload its artifacts")
+	}
+}
+
 func TestCreateBenchmarkResourcesDeletesConfigMapOnJobFailure(t *testing.T) {
 	t.Setenv("SERVICE_URL", "http://service.example")
 	providerID := "provider-1"
@@ -185,8 +328,9 @@ func TestRunEvaluationJobMarksBenchmarkFailedOnCreateError(t *testing.T) {
 	storage := &fakeStorage{logger: logger, ctx: context.Background(), runStatusChan: statusCh}
 	var store abstractions.Storage = storage
 
-	if err := runtime.RunEvaluationJob(evaluation, &store); err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	var benchmarkErr *abstractions.BenchmarkError
+	if err := runtime.RunEvaluationJob(evaluation, &store); !errors.As(err, &benchmarkErr) {
+		t.Fatalf("expected a benchmark error, got %v", err)
 	}
 
 	select {
@@ -208,6 +352,59 @@ func TestRunEvaluationJobMarksBenchmarkFailedOnCreateError(t *testing.T) {
 	}
 }
 
+func TestRunEvaluationJobKeepsSubmittedBenchmarksOnPartialFailure(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	for _, id := range []string{"bench-2", "bench-3"} {
+		evaluation.Benchmarks = append(evaluation.Benchmarks, api.BenchmarkConfig{Ref: api.Ref{ID: id}, ProviderID: providerID, Parameters: map[string]any{"foo": "bar"}})
+	}
+
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+		if job.Name == jobName("job-1", "bench-2") {
+			return true, nil, apierrors.NewForbidden(batchv1.Resource("jobs"), job.Name, fmt.Errorf("exceeded quota: compute-resources"))
+		}
+		return false, nil, nil
+	})
+	runtime := &K8sRuntime{
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:      &KubernetesHelper{clientset: clientset},
+		providers:   sampleProviders(providerID),
+		ctx:         context.Background(),
+		submissions: newSubmissionTracker(),
+	}
+	defer func() { _ = runtime.Shutdown(context.Background()) }()
+	statuses := &statusLog{events: map[string]*api.BenchmarkStatusEvent{}}
+	var store abstractions.Storage = &fakeStorage{ctx: context.Background(), statuses: statuses}
+
+	err := runtime.RunEvaluationJob(evaluation, &store)
+	benchmarkErrs, ok := abstractions.BenchmarkErrors(err)
+	if !ok || len(benchmarkErrs) != 1 {
+		t.Fatalf("expected a single benchmark error, got %v", err)
+	}
+	if benchmarkErrs[0].BenchmarkID != "bench-2" || benchmarkErrs[0].ProviderID != providerID || !apierrors.IsForbidden(err) {
+		t.Fatalf("expected the quota error of bench-2, got %+v", benchmarkErrs[0])
+	}
+
+	for _, id := range []string{"bench-1", "bench-3"} {
+		if _, err := clientset.BatchV1().Jobs(defaultNamespace).Get(context.Background(), jobName("job-1", id), metav1.GetOptions{}); err != nil {
+			t.Fatalf("expected the job of %s to be kept, got %v", id, err)
+		}
+		if status := statuses.get(id); status == nil || status.Status != api.StatePending {
+			t.Fatalf("expected %s to be recorded as pending, got %+v", id, status)
+		}
+	}
+	failed := statuses.get("bench-2")
+	if failed == nil || failed.Status != api.StateFailed || failed.ErrorMessage == nil || !strings.Contains(failed.ErrorMessage.Message, "exceeded quota") {
+		t.Fatalf("expected bench-2 to be recorded as failed with the API error, got %+v", failed)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps(defaultNamespace).Get(context.Background(), configMapName("job-1", "bench-2"), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the configmap of bench-2 to be deleted, got %v", err)
+	}
+}
+
 func TestRunEvaluationJobHandlesUpdateFailure(t *testing.T) {
 	t.Setenv("SERVICE_URL", "http://service.example")
 	providerID := "provider-1"
@@ -235,8 +432,9 @@ func TestRunEvaluationJobHandlesUpdateFailure(t *testing.T) {
 	}
 	var store abstractions.Storage = storage
 
-	if err := runtime.RunEvaluationJob(evaluation, &store); err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	var benchmarkErr *abstractions.BenchmarkError
+	if err := runtime.RunEvaluationJob(evaluation, &store); !errors.As(err, &benchmarkErr) {
+		t.Fatalf("expected a benchmark error, got %v", err)
 	}
 
 	select {
@@ -249,6 +447,427 @@ func TestRunEvaluationJobHandlesUpdateFailure(t *testing.T) {
 	}
 }
 
+func TestRunEvaluationJobRejectsInvalidNamespace(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Benchmarks = append(evaluation.Benchmarks, api.BenchmarkConfig{
+		Ref:        api.Ref{ID: "bench-2"},
+		ProviderID: providerID,
+		Parameters: map[string]any{"foo": "baz"},
+	})
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.Namespace = "bad_namespace"
+
+	clientset := fake.NewSimpleClientset()
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: providers,
+		ctx:       context.Background(),
+	}
+
+	err := runtime.RunEvaluationJob(evaluation, nil)
+	if err == nil {
+		t.Fatalf("expected error for invalid namespace")
+	}
+	for _, id := range []string{"bench-1", "bench-2"} {
+		if !strings.Contains(err.Error(), "benchmark "+id) {
+			t.Fatalf("expected error to mention benchmark %s, got %v", id, err)
+		}
+	}
+	if len(clientset.Actions()) != 0 {
+		t.Fatalf("expected no kubernetes calls, got %d", len(clientset.Actions()))
+	}
+}
+
+func TestRunEvaluationJobRejectsOversizedConfigMap(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Benchmarks = append(evaluation.Benchmarks, api.BenchmarkConfig{
+		Ref:        api.Ref{ID: "bench-big"},
+		ProviderID: providerID,
+		Parameters: map[string]any{"prompt": strings.Repeat("x", maxConfigMapDataBytes)},
+	})
+
+	clientset := fake.NewSimpleClientset()
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: sampleProviders(providerID),
+		ctx:       context.Background(),
+	}
+
+	err := runtime.RunEvaluationJob(evaluation, nil)
+	if err == nil {
+		t.Fatalf("expected error for oversized config")
+	}
+	if !strings.Contains(err.Error(), "benchmark bench-big config exceeds ConfigMap size limit") {
+		t.Fatalf("expected size limit error for bench-big, got %v", err)
+	}
+	if strings.Contains(err.Error(), "bench-1") {
+		t.Fatalf("expected only the oversized benchmark to be reported, got %v", err)
+	}
+	if len(clientset.Actions()) != 0 {
+		t.Fatalf("expected no kubernetes calls, got %d", len(clientset.Actions()))
+	}
+}
+
+func TestNewK8sRuntimeRejectsInvalidNamespace(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.Namespace = "Invalid.Namespace"
+
+	if _, err := NewK8sRuntime(slog.New(slog.NewTextHandler(io.Discard, nil)), providers, nil, nil); err == nil {
+		t.Fatalf("expected error for invalid namespace")
+	}
+}
+
+func TestCancelEvaluationJobDeletesLabelledResources(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.Namespace = "eval-jobs"
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job-a", Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-1")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job-b", Namespace: "eval-jobs", Labels: jobLabels("job-2", "provider-1", "bench-1")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spec-a", Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-1")}},
+	)
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: providers,
+		ctx:       ctx,
+	}
+
+	if err := runtime.CancelEvaluationJob("job-1"); err != nil {
+		t.Fatalf("CancelEvaluationJob returned error: %v", err)
+	}
+	if _, err := clientset.BatchV1().Jobs("eval-jobs").Get(ctx, "job-a", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected job-a to be deleted, got %v", err)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps("eval-jobs").Get(ctx, "spec-a", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected spec-a to be deleted, got %v", err)
+	}
+	if _, err := clientset.BatchV1().Jobs("eval-jobs").Get(ctx, "job-b", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected job-b of another evaluation to be kept, got %v", err)
+	}
+}
+
+func TestCancelEvaluationJobHonorsContextDeadline(t *testing.T) {
+	// the API server answers no request until the test is over
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: sampleProviders("provider-1"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err = runtime.WithContext(ctx).CancelEvaluationJob("job-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to abort the cancel, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("expected the cancel to return at the deadline, took %s", elapsed)
+	}
+}
+
+func TestRunEvaluationJobOutlivesRequestContext(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	clientset := fake.NewSimpleClientset()
+	var storage abstractions.Storage = &fakeStorage{}
+	ctx, cancel := context.WithCancel(context.Background())
+	runtime := &K8sRuntime{
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:      &KubernetesHelper{clientset: clientset},
+		providers:   sampleProviders("provider-1"),
+		submissions: newSubmissionTracker(),
+	}
+	defer func() { _ = runtime.Shutdown(context.Background()) }()
+	evaluation := sampleEvaluation("provider-1")
+
+	if err := runtime.WithContext(ctx).RunEvaluationJob(evaluation, &storage); err != nil {
+		t.Fatalf("RunEvaluationJob returned error: %v", err)
+	}
+	// the request is over once the job is accepted
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		jobs, err := clientset.BatchV1().Jobs(defaultNamespace).List(context.Background(), metav1.ListOptions{})
+		if err == nil && len(jobs.Items) == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected the benchmark job to be created after the request ended")
+}
+
+func TestCountActiveJobsSkipsFinishedJobs(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.Namespace = "eval-jobs"
+	finished := func(conditionType batchv1.JobConditionType) batchv1.JobStatus {
+		return batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}}
+	}
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job-a", Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-1")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job-b", Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-2")}, Status: finished(batchv1.JobComplete)},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job-c", Namespace: "eval-jobs", Labels: jobLabels("job-2", "provider-1", "bench-1")}, Status: finished(batchv1.JobFailed)},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "eval-jobs"}},
+	)
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: providers,
+		ctx:       context.Background(),
+	}
+
+	count, err := runtime.CountActiveJobs()
+	if err != nil {
+		t.Fatalf("CountActiveJobs returned error: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 active job, got %d", count)
+	}
+}
+
+func TestStreamBenchmarkLogsReadsLatestPod(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.Namespace = "eval-jobs"
+	name := jobName("job-1", "bench-1")
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name + "-abcde", Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-1")}},
+	)
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: providers,
+		ctx:       context.Background(),
+	}
+
+	logs, err := runtime.StreamBenchmarkLogs("job-1", "bench-1", abstractions.LogOptions{})
+	if err != nil {
+		t.Fatalf("StreamBenchmarkLogs returned error: %v", err)
+	}
+	defer logs.Close()
+	content, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatalf("failed to read logs: %v", err)
+	}
+	if len(content) == 0 {
+		t.Fatalf("expected logs from the pod")
+	}
+
+	_, err = runtime.StreamBenchmarkLogs("job-1", "bench-2", abstractions.LogOptions{})
+	if !errors.Is(err, abstractions.ErrBenchmarkNotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func limitedRuntime(t *testing.T, clientset *fake.Clientset, limit int) (*K8sRuntime, *api.EvaluationJobResource) {
+	t.Helper()
+	t.Setenv("SERVICE_URL", "http://service.example")
+	previousResync := jobWatchResync
+	jobWatchResync = 50 * time.Millisecond
+	t.Cleanup(func() { jobWatchResync = previousResync })
+
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Benchmarks = append(evaluation.Benchmarks, api.BenchmarkConfig{
+		Ref:        api.Ref{ID: "bench-2"},
+		ProviderID: providerID,
+		Parameters: map[string]any{"foo": "baz"},
+	})
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.MaxConcurrentBenchmarks = limit
+	return &K8sRuntime{
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:      &KubernetesHelper{clientset: clientset},
+		providers:   providers,
+		ctx:         context.Background(),
+		submissions: newSubmissionTracker(),
+	}, evaluation
+}
+
+func waitForJobCount(t *testing.T, clientset *fake.Clientset, count int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		jobs, err := clientset.BatchV1().Jobs(defaultNamespace).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("failed to list jobs: %v", err)
+		}
+		if len(jobs.Items) == count {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d jobs, got %d", count, len(jobs.Items))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunEvaluationJobSubmitsLimitedBenchmarksAsJobsFinish(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	runtime, evaluation := limitedRuntime(t, clientset, 1)
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	waitForJobCount(t, clientset, 1)
+	time.Sleep(100 * time.Millisecond)
+	waitForJobCount(t, clientset, 1)
+
+	ctx := context.Background()
+	first, err := clientset.BatchV1().Jobs(defaultNamespace).Get(ctx, jobName("job-1", "bench-1"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the first benchmark job to be created, got %v", err)
+	}
+	first.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if _, err := clientset.BatchV1().Jobs(defaultNamespace).UpdateStatus(ctx, first, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to complete the first job: %v", err)
+	}
+	waitForJobCount(t, clientset, 2)
+}
+
+func TestCancelEvaluationJobStopsPendingSubmissions(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	runtime, evaluation := limitedRuntime(t, clientset, 1)
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	waitForJobCount(t, clientset, 1)
+
+	if err := runtime.WithLogger(runtime.logger).CancelEvaluationJob("job-1"); err != nil {
+		t.Fatalf("CancelEvaluationJob returned error: %v", err)
+	}
+	waitForJobCount(t, clientset, 0)
+	time.Sleep(200 * time.Millisecond)
+	waitForJobCount(t, clientset, 0)
+	if runtime.submissions.cancel("job-1") {
+		t.Fatalf("expected the submissions of the job to be done")
+	}
+}
+
+func TestCancelBenchmarkDeletesOnlyItsResources(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.Namespace = "eval-jobs"
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: jobName("job-1", "bench-1"), Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-1")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: jobName("job-1", "bench-2"), Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-2")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configMapName("job-1", "bench-1"), Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-1")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configMapName("job-1", "bench-2"), Namespace: "eval-jobs", Labels: jobLabels("job-1", "provider-1", "bench-2")}},
+	)
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: providers,
+		ctx:       ctx,
+	}
+
+	if err := runtime.CancelBenchmark("job-1", "bench-1"); err != nil {
+		t.Fatalf("CancelBenchmark returned error: %v", err)
+	}
+	if _, err := clientset.BatchV1().Jobs("eval-jobs").Get(ctx, jobName("job-1", "bench-1"), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the job of bench-1 to be deleted, got %v", err)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps("eval-jobs").Get(ctx, configMapName("job-1", "bench-1"), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the configmap of bench-1 to be deleted, got %v", err)
+	}
+	if _, err := clientset.BatchV1().Jobs("eval-jobs").Get(ctx, jobName("job-1", "bench-2"), metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the job of bench-2 to be kept, got %v", err)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps("eval-jobs").Get(ctx, configMapName("job-1", "bench-2"), metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the configmap of bench-2 to be kept, got %v", err)
+	}
+
+	// the resources are gone, cancelling again is not an error
+	if err := runtime.CancelBenchmark("job-1", "bench-1"); err != nil {
+		t.Fatalf("expected a second cancel to succeed, got %v", err)
+	}
+}
+
+func TestCancelBenchmarkSkipsQueuedBenchmark(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	runtime, evaluation := limitedRuntime(t, clientset, 1)
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	waitForJobCount(t, clientset, 1)
+
+	// bench-2 waits for the slot of bench-1, which the cancel of bench-1 frees
+	if err := runtime.CancelBenchmark("job-1", "bench-2"); err != nil {
+		t.Fatalf("CancelBenchmark returned error: %v", err)
+	}
+	if err := runtime.CancelBenchmark("job-1", "bench-1"); err != nil {
+		t.Fatalf("CancelBenchmark returned error: %v", err)
+	}
+	waitForJobCount(t, clientset, 0)
+	time.Sleep(200 * time.Millisecond)
+	waitForJobCount(t, clientset, 0)
+	if runtime.submissions.cancel("job-1") {
+		t.Fatalf("expected the submissions of the job to be done")
+	}
+}
+
+func TestShutdownStopsWatchersAndRefusesJobs(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	runtime, evaluation := limitedRuntime(t, clientset, 1)
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	waitForJobCount(t, clientset, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := runtime.WithLogger(runtime.logger).Shutdown(ctx); err != nil {
+		t.Fatalf("expected the watcher to stop before the deadline, got %v", err)
+	}
+	// the second benchmark waits for the first one, it is not submitted once nothing watches
+	time.Sleep(100 * time.Millisecond)
+	waitForJobCount(t, clientset, 1)
+
+	if err := runtime.RunEvaluationJob(evaluation, nil); !errors.Is(err, errShuttingDown) {
+		t.Fatalf("expected the job to be refused, got %v", err)
+	}
+}
+
+func TestNewK8sRuntimeRejectsNegativeConcurrency(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.MaxConcurrentBenchmarks = -1
+
+	if _, err := NewK8sRuntime(slog.New(slog.NewTextHandler(io.Discard, nil)), providers, nil, nil); err == nil || !strings.Contains(err.Error(), "max_concurrent_benchmarks") {
+		t.Fatalf("expected error for negative max_concurrent_benchmarks, got %v", err)
+	}
+}
+
+func TestNewK8sRuntimeRejectsInvalidLabels(t *testing.T) {
+	providers := sampleProviders("provider-1")
+	providers["provider-1"].Runtime.K8s.Labels = map[string]string{"eval-hub/job-id": "other"}
+
+	if _, err := NewK8sRuntime(slog.New(slog.NewTextHandler(io.Discard, nil)), providers, nil, nil); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Fatalf("expected error for a reserved label, got %v", err)
+	}
+}
+
 func sampleEvaluation(providerID string) *api.EvaluationJobResource {
 	return &api.EvaluationJobResource{
 		Resource: api.EvaluationResource{
@@ -288,3 +907,181 @@ func sampleProviders(providerID string) map[string]api.ProviderResource {
 		},
 	}
 }
+
+func TestCollectBenchmarkResults(t *testing.T) {
+	cases := []struct {
+		name          string
+		message       string
+		expectMetrics bool
+		truncated     bool
+	}{
+		{name: "results", message: `{"metrics": {"accuracy": 0.82, "f1": 0.79}}`, expectMetrics: true},
+		{name: "unparseable results", message: `accuracy=0.82`},
+		{name: "missing results", message: ""},
+		{name: "truncated results", message: `{"metrics": {"accuracy": 0.82, ` + strings.Repeat(" ", api.MaxBenchmarkResultsSize), truncated: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			providerID := "provider-1"
+			evaluation := sampleEvaluation(providerID)
+			benchmarkLabels := jobLabels("job-1", providerID, "bench-1")
+
+			clientset := fake.NewSimpleClientset(
+				&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{Name: jobName("job-1", "bench-1"), Namespace: defaultNamespace, Labels: benchmarkLabels},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "bench-1-pod", Namespace: defaultNamespace, Labels: benchmarkLabels},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name:  adapterContainerName,
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Message: tc.message}},
+						}},
+					},
+				},
+			)
+			runtime := &K8sRuntime{
+				logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+				helper:    &KubernetesHelper{clientset: clientset},
+				providers: sampleProviders(providerID),
+				ctx:       ctx,
+			}
+			recorder := &fakeStorage{}
+			var storage abstractions.Storage = recorder
+
+			runtime.awaitBenchmark(ctx, evaluation, &storage, &evaluation.Benchmarks[0])
+			if recorder.runStatus == nil {
+				t.Fatalf("expected the results to be recorded")
+			}
+			event := recorder.runStatus.BenchmarkStatusEvent
+			if event.Status != api.StateCompleted || event.ID != "bench-1" {
+				t.Fatalf("expected the benchmark to be completed, got %+v", event)
+			}
+			if tc.expectMetrics {
+				if len(event.Metrics) != 2 || event.Metrics["accuracy"] != 0.82 || event.Message != nil {
+					t.Fatalf("unexpected metrics %+v", event.Metrics)
+				}
+				return
+			}
+			if event.Metrics != nil || event.Message == nil || event.Message.MessageCode != constants.MESSAGE_CODE_BENCHMARK_NO_METRICS {
+				t.Fatalf("expected the benchmark to be completed without metrics, got %+v", event)
+			}
+			if strings.Contains(event.Message.Message, "truncated") != tc.truncated {
+				t.Fatalf("unexpected message %q", event.Message.Message)
+			}
+		})
+	}
+}
+
+func TestCollectBenchmarkResultsRecordsFailedJob(t *testing.T) {
+	ctx := context.Background()
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobName("job-1", "bench-1"), Namespace: defaultNamespace, Labels: jobLabels("job-1", providerID, "bench-1")},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonDeadlineExceeded,
+					Message: "Job was active longer than specified deadline",
+				}},
+			},
+		},
+	)
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: sampleProviders(providerID),
+		ctx:       ctx,
+	}
+	recorder := &fakeStorage{}
+	var storage abstractions.Storage = recorder
+
+	runtime.awaitBenchmark(ctx, evaluation, &storage, &evaluation.Benchmarks[0])
+	if recorder.runStatus == nil {
+		t.Fatalf("expected the failure to be recorded")
+	}
+	event := recorder.runStatus.BenchmarkStatusEvent
+	if event.Status != api.StateFailed || event.ID != "bench-1" {
+		t.Fatalf("expected the benchmark to be failed, got %+v", event)
+	}
+	if event.ErrorMessage == nil || !strings.Contains(event.ErrorMessage.Message, "DeadlineExceeded") || !strings.Contains(event.ErrorMessage.Message, "longer than specified deadline") {
+		t.Fatalf("expected the reason of the failure, got %+v", event.ErrorMessage)
+	}
+}
+
+func TestCreateBenchmarkResourcesReplacesStaleResources(t *testing.T) {
+	t.Setenv("SERVICE_URL", "http://service.example")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	benchmarkLabels := jobLabels("job-1", providerID, "bench-1")
+
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobName("job-1", "bench-1"), Namespace: defaultNamespace, Labels: benchmarkLabels, UID: "stale"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configMapName("job-1", "bench-1"), Namespace: defaultNamespace, Labels: benchmarkLabels},
+		},
+	)
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: clientset},
+		providers: sampleProviders(providerID),
+		ctx:       context.Background(),
+	}
+
+	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0]); err != nil {
+		t.Fatalf("expected the stale resources to be replaced, got %v", err)
+	}
+	job, err := clientset.BatchV1().Jobs(defaultNamespace).Get(context.Background(), jobName("job-1", "bench-1"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the job to be created, got %v", err)
+	}
+	if job.UID == "stale" || len(job.Spec.Template.Spec.Containers) == 0 {
+		t.Fatalf("expected a new job, got %+v", job)
+	}
+}
+
+func TestReadinessChecksPingAPIServer(t *testing.T) {
+	runtime := &K8sRuntime{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:    &KubernetesHelper{clientset: fake.NewSimpleClientset()},
+		providers: sampleProviders("provider-1"),
+	}
+	checks := runtime.ReadinessChecks()
+	if len(checks) != 1 || checks[0].Name != "kubernetes" {
+		t.Fatalf("expected a kubernetes check, got %+v", checks)
+	}
+	if err := checks[0].Check(context.Background()); err != nil {
+		t.Fatalf("expected the API server to be reachable, got %v", err)
+	}
+
+	// an API server that does not answer fails the check at the deadline
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+	runtime.helper = &KubernetesHelper{clientset: clientset}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := runtime.ReadinessChecks()[0].Check(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to fail the check, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package k8s
+
+// Collection of the scores of the finished benchmark Jobs.
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// awaitBenchmark waits for the Job of the submitted benchmark to finish and then collects
+// the scores of the benchmark.
+func (r *K8sRuntime) awaitBenchmark(ctx context.Context, evaluation *api.EvaluationJobResource, storage *abstractions.Storage, bench *api.BenchmarkConfig) {
+	provider := r.providers[bench.ProviderID]
+	namespace, _ := resolveNamespace(provider.Runtime.K8s.Namespace)
+	err := r.helper.WaitForJobsFinished(ctx, namespace, benchmarkSelector(evaluation.Resource.ID, bench.ID), jobWatchResync)
+	if err == nil {
+		err = r.collectBenchmarkResults(ctx, namespace, evaluation, storage, bench)
+	}
+	if err != nil && ctx.Err() == nil {
+		r.logger.Error(
+			"kubernetes job watch failed",
+			"error", err,
+			"job_id", evaluation.Resource.ID,
+			"benchmark_id", bench.ID,
+		)
+	}
+}
+
+// collectBenchmarkResults reads the results file the adapter of a succeeded benchmark Job
+// wrote and merges its metrics into the stored results of the benchmark. A benchmark whose
+// results are missing or can not be parsed is recorded as completed without metrics. A Job
+// that failed, such as one that exceeded its deadline or its backoff limit, is recorded as
+// failed with the reason Kubernetes gives, as its adapter may never have reported.
+func (r *K8sRuntime) collectBenchmarkResults(ctx context.Context, namespace string, evaluation *api.EvaluationJobResource, storage *abstractions.Storage, bench *api.BenchmarkConfig) error {
+	if storage == nil || *storage == nil {
+		return nil
+	}
+	selector := benchmarkSelector(evaluation.Resource.ID, bench.ID)
+	jobs, err := r.helper.ListJobs(ctx, namespace, selector)
+	if err != nil {
+		return err
+	}
+	var succeeded *batchv1.Job
+	var failed *batchv1.JobCondition
+	for i := range jobs {
+		if isJobSucceeded(&jobs[i]) {
+			succeeded = &jobs[i]
+		} else if condition := jobFailedCondition(&jobs[i]); condition != nil {
+			failed = condition
+		}
+	}
+	if succeeded == nil {
+		if failed == nil {
+			return nil
+		}
+		if err := (*storage).UpdateEvaluationJob(evaluation.Resource.ID, buildJobFailureStatus(bench, failed)); err != nil {
+			return fmt.Errorf("record failure: %w", err)
+		}
+		return nil
+	}
+	pods, err := r.helper.ListPods(ctx, namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	status := &api.BenchmarkStatusEvent{
+		ProviderID: bench.ProviderID,
+		ID:         bench.ID,
+		Status:     api.StateCompleted,
+	}
+	if succeeded.Status.CompletionTime != nil {
+		completedAt := succeeded.Status.CompletionTime.Time
+		status.CompletedAt = &completedAt
+	}
+	results, err := parseBenchmarkResults(pods)
+	if err != nil {
+		message := "benchmark completed without metrics"
+		if errors.Is(err, errResultsTruncated) {
+			message = "benchmark results truncated, completed without metrics"
+		}
+		r.logger.Warn(
+			message,
+			"error", err,
+			"job_id", evaluation.Resource.ID,
+			"benchmark_id", bench.ID,
+		)
+		status.Message = &api.MessageInfo{
+			Message:     fmt.Sprintf("benchmark completed without metrics: %s", err.Error()),
+			MessageCode: constants.MESSAGE_CODE_BENCHMARK_NO_METRICS,
+		}
+	} else {
+		status.Metrics = results.MetricsMap()
+	}
+	if err := (*storage).UpdateEvaluationJob(evaluation.Resource.ID, &api.StatusEvent{BenchmarkStatusEvent: status}); err != nil {
+		return fmt.Errorf("record results: %w", err)
+	}
+	return nil
+}
+
+// errResultsTruncated is returned for a results file the kubelet truncated, as it was larger
+// than api.MaxBenchmarkResultsSize.
+var errResultsTruncated = fmt.Errorf("%s is larger than %d bytes and was truncated", api.BenchmarkResultsFile, api.MaxBenchmarkResultsSize)
+
+// parseBenchmarkResults parses the results file of the adapter container that last exited
+// successfully, which the kubelet reports as its termination message.
+func parseBenchmarkResults(pods []corev1.Pod) (*api.BenchmarkResults, error) {
+	var latest *corev1.ContainerStateTerminated
+	for i := range pods {
+		for _, container := range pods[i].Status.ContainerStatuses {
+			terminated := container.State.Terminated
+			if container.Name != adapterContainerName || terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+			if latest == nil || latest.FinishedAt.Before(&terminated.FinishedAt) {
+				latest = terminated
+			}
+		}
+	}
+	if latest == nil || latest.Message == "" {
+		return nil, errors.New("no " + api.BenchmarkResultsFile + " was written")
+	}
+	results, err := api.ParseBenchmarkResults([]byte(latest.Message))
+	if err != nil && len(latest.Message) >= api.MaxBenchmarkResultsSize {
+		return nil, fmt.Errorf("%w: %w", errResultsTruncated, err)
+	}
+	return results, err
+}
+
+// buildJobFailureStatus records the benchmark as failed with the reason and the message of
+// the Failed condition of its Job.
+func buildJobFailureStatus(bench *api.BenchmarkConfig, condition *batchv1.JobCondition) *api.StatusEvent {
+	message := "benchmark job failed"
+	if condition.Reason != "" {
+		message += ": " + condition.Reason
+	}
+	if condition.Message != "" {
+		message += ": " + condition.Message
+	}
+	status := &api.BenchmarkStatusEvent{
+		ProviderID:   bench.ProviderID,
+		ID:           bench.ID,
+		Status:       api.StateFailed,
+		ErrorMessage: &api.MessageInfo{Message: message, MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED},
+	}
+	if !condition.LastTransitionTime.IsZero() {
+		completedAt := condition.LastTransitionTime.Time
+		status.CompletedAt = &completedAt
+	}
+	return &api.StatusEvent{BenchmarkStatusEvent: status}
+}
+
+// jobFailedCondition returns the Failed condition of the Job, nil when it did not fail.
+func jobFailedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+func isJobSucceeded(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
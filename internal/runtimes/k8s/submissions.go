@@ -0,0 +1,160 @@
+package k8s
+
+// Tracking of the benchmark submissions that are still in progress.
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errShuttingDown is returned for a job that is run after the runtime started to shut down.
+var errShuttingDown = errors.New("the kubernetes runtime is shutting down")
+
+// submissionTracker holds the cancel functions of the evaluation jobs whose benchmarks are
+// still being submitted or awaited. It is shared by the copies of the runtime so that a
+// cancel reaches the submissions started by another request, a nil tracker does not track
+// anything. A job has more than one submission when its failed benchmarks are retried.
+// The benchmarks cancelled on their own while the job has submissions are kept so that
+// they are not submitted afterwards.
+//
+// The tracker also drives the shutdown of the runtime: the watchers of the submitted Jobs
+// stop as soon as it starts, the submissions are waited for until its deadline.
+type submissionTracker struct {
+	mu          sync.Mutex
+	submissions map[string]map[*submission]bool
+	canceled    map[string]map[string]bool
+	closing     bool
+	running     sync.WaitGroup
+	// watching is done when the watchers must stop, submitting when the submissions must
+	watching       context.Context
+	stopWatching   context.CancelFunc
+	submitting     context.Context
+	stopSubmitting context.CancelFunc
+}
+
+type submission struct {
+	cancel context.CancelFunc
+}
+
+func newSubmissionTracker() *submissionTracker {
+	watching, stopWatching := context.WithCancel(context.Background())
+	submitting, stopSubmitting := context.WithCancel(context.Background())
+	return &submissionTracker{
+		submissions:    map[string]map[*submission]bool{},
+		canceled:       map[string]map[string]bool{},
+		watching:       watching,
+		stopWatching:   stopWatching,
+		submitting:     submitting,
+		stopSubmitting: stopSubmitting,
+	}
+}
+
+// start returns the context of the submissions of the job, the context of the watchers of
+// its Jobs and the function to call once they are done. It fails once the runtime is
+// shutting down.
+func (t *submissionTracker) start(parent context.Context, jobID string) (context.Context, context.Context, func(), error) {
+	ctx, cancel := context.WithCancel(parent)
+	if t == nil {
+		return ctx, ctx, cancel, nil
+	}
+	t.mu.Lock()
+	if t.closing {
+		t.mu.Unlock()
+		cancel()
+		return nil, nil, nil, errShuttingDown
+	}
+	current := &submission{cancel: cancel}
+	if t.submissions[jobID] == nil {
+		t.submissions[jobID] = map[*submission]bool{}
+	}
+	t.submissions[jobID][current] = true
+	t.running.Add(1)
+	t.mu.Unlock()
+
+	stopSubmitting := context.AfterFunc(t.submitting, cancel)
+	watchCtx, cancelWatch := context.WithCancel(ctx)
+	stopWatching := context.AfterFunc(t.watching, cancelWatch)
+	return ctx, watchCtx, func() {
+		stopWatching()
+		stopSubmitting()
+		cancelWatch()
+		cancel()
+		t.mu.Lock()
+		delete(t.submissions[jobID], current)
+		if len(t.submissions[jobID]) == 0 {
+			delete(t.submissions, jobID)
+			delete(t.canceled, jobID)
+		}
+		t.mu.Unlock()
+		t.running.Done()
+	}, nil
+}
+
+// cancel stops the pending submissions of the job, it returns false when there are none.
+func (t *submissionTracker) cancel(jobID string) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	current, ok := t.submissions[jobID]
+	delete(t.submissions, jobID)
+	delete(t.canceled, jobID)
+	t.mu.Unlock()
+	for submission := range current {
+		submission.cancel()
+	}
+	return ok
+}
+
+// cancelBenchmark keeps the pending submissions of the job from submitting the benchmark.
+func (t *submissionTracker) cancelBenchmark(jobID, benchmarkID string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.submissions[jobID]) == 0 {
+		return
+	}
+	if t.canceled[jobID] == nil {
+		t.canceled[jobID] = map[string]bool{}
+	}
+	t.canceled[jobID][benchmarkID] = true
+}
+
+// benchmarkCanceled reports whether the benchmark of the job was cancelled on its own.
+func (t *submissionTracker) benchmarkCanceled(jobID, benchmarkID string) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.canceled[jobID][benchmarkID]
+}
+
+// shutdown refuses new submissions, stops the watchers and waits for the submissions in
+// progress to finish. The ones still running when the context is done are stopped.
+func (t *submissionTracker) shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	t.closing = true
+	t.mu.Unlock()
+	t.stopWatching()
+
+	finished := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		t.stopSubmitting()
+		return nil
+	case <-ctx.Done():
+		t.stopSubmitting()
+		return fmt.Errorf("benchmark submissions still running: %w", ctx.Err())
+	}
+}
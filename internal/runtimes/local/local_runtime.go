@@ -2,6 +2,8 @@ package local
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
@@ -35,6 +37,31 @@ func (r *LocalRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, s
 	return nil
 }
 
+func (r *LocalRuntime) CancelEvaluationJob(jobID string) error {
+	return nil
+}
+
+func (r *LocalRuntime) CancelBenchmark(jobID string, benchmarkID string) error {
+	return nil
+}
+
+func (r *LocalRuntime) CountActiveJobs() (int, error) {
+	return 0, nil
+}
+
+func (r *LocalRuntime) StreamBenchmarkLogs(jobID string, benchmarkID string, options abstractions.LogOptions) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("job %s benchmark %s: %w", jobID, benchmarkID, abstractions.ErrBenchmarkNotFound)
+}
+
+// RenderEvaluationJob returns no resources, the local runtime does not create any.
+func (r *LocalRuntime) RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error) {
+	return []api.RenderedResource{}, nil
+}
+
+func (r *LocalRuntime) Shutdown(ctx context.Context) error {
+	return nil
+}
+
 func (r *LocalRuntime) Name() string {
 	return "local"
 }
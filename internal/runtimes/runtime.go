@@ -1,24 +1,241 @@
 package runtimes
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"strings"
 
 	"github.com/eval-hub/eval-hub/internal/abstractions"
 	"github.com/eval-hub/eval-hub/internal/config"
+	"github.com/eval-hub/eval-hub/internal/runtimes/docker"
 	"github.com/eval-hub/eval-hub/internal/runtimes/k8s"
 	"github.com/eval-hub/eval-hub/internal/runtimes/local"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 func NewRuntime(logger *slog.Logger, serviceConfig *config.Config, providerConfigs map[string]api.ProviderResource) (abstractions.Runtime, error) {
-	var runtime abstractions.Runtime
-	var err error
-
 	if serviceConfig.Service.LocalMode {
-		runtime, err = local.NewLocalRuntime(logger)
-	} else {
-		runtime, err = k8s.NewK8sRuntime(logger, providerConfigs)
+		return local.NewLocalRuntime(logger)
+	}
+
+	// A provider runs on Docker when docker is its only runtime, everything else
+	// (including providers without a runtime) keeps running on Kubernetes.
+	dockerProviders := map[string]bool{}
+	needsK8s := len(providerConfigs) == 0
+	for id, provider := range providerConfigs {
+		if provider.Runtime != nil && provider.Runtime.K8s == nil && provider.Runtime.Docker != nil {
+			dockerProviders[id] = true
+		} else {
+			needsK8s = true
+		}
+	}
+
+	var backends []abstractions.Runtime
+	providers := map[string]int{}
+	fallback := -1
+	if needsK8s {
+		runtime, err := k8s.NewK8sRuntime(logger, providerConfigs, serviceConfig.Artifacts, serviceConfig.Cleanup)
+		if err != nil {
+			return nil, err
+		}
+		fallback = len(backends)
+		backends = append(backends, runtime)
+	}
+	if len(dockerProviders) > 0 {
+		runtime, err := docker.NewDockerRuntime(logger, providerConfigs)
+		if err != nil {
+			return nil, err
+		}
+		for id := range dockerProviders {
+			providers[id] = len(backends)
+		}
+		backends = append(backends, runtime)
+	}
+	if len(backends) == 1 {
+		return backends[0], nil
+	}
+	return &ProviderRuntime{runtimes: backends, providers: providers, fallback: fallback}, nil
+}
+
+// ProviderRuntime sends the benchmarks of an evaluation job to the runtime of their provider.
+type ProviderRuntime struct {
+	runtimes []abstractions.Runtime
+	// providers maps a provider ID to its index in runtimes, providers that are not
+	// listed use the fallback runtime.
+	providers map[string]int
+	fallback  int
+}
+
+func (r *ProviderRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
+	runtimes := make([]abstractions.Runtime, len(r.runtimes))
+	for i, runtime := range r.runtimes {
+		runtimes[i] = runtime.WithLogger(logger)
+	}
+	return &ProviderRuntime{runtimes: runtimes, providers: r.providers, fallback: r.fallback}
+}
+
+func (r *ProviderRuntime) WithContext(ctx context.Context) abstractions.Runtime {
+	runtimes := make([]abstractions.Runtime, len(r.runtimes))
+	for i, runtime := range r.runtimes {
+		runtimes[i] = runtime.WithContext(ctx)
+	}
+	return &ProviderRuntime{runtimes: runtimes, providers: r.providers, fallback: r.fallback}
+}
+
+func (r *ProviderRuntime) Name() string {
+	names := make([]string, 0, len(r.runtimes))
+	for _, runtime := range r.runtimes {
+		names = append(names, runtime.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// RunEvaluationJob splits the benchmarks of the job by runtime and runs each group on its
+// runtime. Every runtime validates its benchmarks before submitting them, so a configuration
+// error only stops the benchmarks of that runtime.
+func (r *ProviderRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, storage *abstractions.Storage) error {
+	groups, err := r.groupBenchmarks(evaluation)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, benchmarks := range groups {
+		if len(benchmarks) == 0 {
+			continue
+		}
+		subset := *evaluation
+		subset.Benchmarks = benchmarks
+		if err := r.runtimes[i].RunEvaluationJob(&subset, storage); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RenderEvaluationJob renders the benchmarks of the job on the runtime of their provider.
+func (r *ProviderRuntime) RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error) {
+	groups, err := r.groupBenchmarks(evaluation)
+	if err != nil {
+		return nil, err
+	}
+	rendered := []api.RenderedResource{}
+	var errs []error
+	for i, benchmarks := range groups {
+		if len(benchmarks) == 0 {
+			continue
+		}
+		subset := *evaluation
+		subset.Benchmarks = benchmarks
+		resources, err := r.runtimes[i].RenderEvaluationJob(&subset)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rendered = append(rendered, resources...)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rendered, nil
+}
+
+// groupBenchmarks splits the benchmarks of the job by the index of their runtime.
+func (r *ProviderRuntime) groupBenchmarks(evaluation *api.EvaluationJobResource) ([][]api.BenchmarkConfig, error) {
+	groups := make([][]api.BenchmarkConfig, len(r.runtimes))
+	var errs []error
+	for _, benchmark := range evaluation.Benchmarks {
+		index, ok := r.providers[benchmark.ProviderID]
+		if !ok {
+			index = r.fallback
+		}
+		if index < 0 {
+			errs = append(errs, fmt.Errorf("benchmark %s: no runtime for provider %s", benchmark.ID, benchmark.ProviderID))
+			continue
+		}
+		groups[index] = append(groups[index], benchmark)
+	}
+	return groups, errors.Join(errs...)
+}
+
+// CancelEvaluationJob cancels the job on every runtime, as benchmarks of a single job can
+// be spread across runtimes.
+func (r *ProviderRuntime) CancelEvaluationJob(jobID string) error {
+	var errs []error
+	for _, runtime := range r.runtimes {
+		if err := runtime.CancelEvaluationJob(jobID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CancelBenchmark cancels the benchmark on every runtime, the runtimes that did not run it
+// have nothing to remove.
+func (r *ProviderRuntime) CancelBenchmark(jobID string, benchmarkID string) error {
+	var errs []error
+	for _, runtime := range r.runtimes {
+		if err := runtime.CancelBenchmark(jobID, benchmarkID); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts every runtime down, they share the deadline of the context.
+func (r *ProviderRuntime) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, runtime := range r.runtimes {
+		if err := runtime.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReadinessChecks returns the readiness checks of every runtime that has some.
+func (r *ProviderRuntime) ReadinessChecks() []abstractions.ReadinessCheck {
+	var checks []abstractions.ReadinessCheck
+	for _, runtime := range r.runtimes {
+		if checker, ok := runtime.(abstractions.ReadinessChecker); ok {
+			checks = append(checks, checker.ReadinessChecks()...)
+		}
+	}
+	return checks
+}
+
+// CountActiveJobs returns the sum of the active jobs of every runtime.
+func (r *ProviderRuntime) CountActiveJobs() (int, error) {
+	total := 0
+	var errs []error
+	for _, runtime := range r.runtimes {
+		count, err := runtime.CountActiveJobs()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total += count
+	}
+	return total, errors.Join(errs...)
+}
 
-	return runtime, err
+// StreamBenchmarkLogs returns the logs from the first runtime that has a workload for the
+// benchmark, as the runtime of a provider can not be told from the job and benchmark IDs.
+func (r *ProviderRuntime) StreamBenchmarkLogs(jobID string, benchmarkID string, options abstractions.LogOptions) (io.ReadCloser, error) {
+	var errs []error
+	for _, runtime := range r.runtimes {
+		logs, err := runtime.StreamBenchmarkLogs(jobID, benchmarkID, options)
+		if err == nil {
+			return logs, nil
+		}
+		if !errors.Is(err, abstractions.ErrBenchmarkNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return nil, fmt.Errorf("job %s benchmark %s: %w", jobID, benchmarkID, abstractions.ErrBenchmarkNotFound)
 }
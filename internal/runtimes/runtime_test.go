@@ -0,0 +1,238 @@
+package runtimes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type recordingRuntime struct {
+	name       string
+	err        error
+	benchmarks []string
+	cancelled  []string
+	active     int
+	logs       string
+	shutdown   bool
+}
+
+func (r *recordingRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime     { return r }
+func (r *recordingRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+func (r *recordingRuntime) Name() string                                       { return r.name }
+func (r *recordingRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, _ *abstractions.Storage) error {
+	for _, benchmark := range evaluation.Benchmarks {
+		r.benchmarks = append(r.benchmarks, benchmark.ID)
+	}
+	return r.err
+}
+func (r *recordingRuntime) RenderEvaluationJob(evaluation *api.EvaluationJobResource) ([]api.RenderedResource, error) {
+	resources := []api.RenderedResource{}
+	for _, benchmark := range evaluation.Benchmarks {
+		resources = append(resources, api.RenderedResource{Kind: r.name, BenchmarkID: benchmark.ID})
+	}
+	return resources, r.err
+}
+func (r *recordingRuntime) CancelEvaluationJob(jobID string) error {
+	r.cancelled = append(r.cancelled, jobID)
+	return nil
+}
+
+func (r *recordingRuntime) CancelBenchmark(jobID string, benchmarkID string) error {
+	return nil
+}
+
+func (r *recordingRuntime) CountActiveJobs() (int, error) {
+	return r.active, nil
+}
+
+func (r *recordingRuntime) Shutdown(_ context.Context) error {
+	r.shutdown = true
+	return r.err
+}
+
+func (r *recordingRuntime) StreamBenchmarkLogs(jobID string, benchmarkID string, _ abstractions.LogOptions) (io.ReadCloser, error) {
+	if r.logs == "" {
+		return nil, fmt.Errorf("job %s benchmark %s: %w", jobID, benchmarkID, abstractions.ErrBenchmarkNotFound)
+	}
+	return io.NopCloser(strings.NewReader(r.logs)), nil
+}
+
+func evaluationWithBenchmarks(providers ...string) *api.EvaluationJobResource {
+	evaluation := &api.EvaluationJobResource{}
+	for i, provider := range providers {
+		evaluation.Benchmarks = append(evaluation.Benchmarks, api.BenchmarkConfig{
+			Ref:        api.Ref{ID: "bench-" + string(rune('a'+i))},
+			ProviderID: provider,
+		})
+	}
+	return evaluation
+}
+
+func TestProviderRuntimeDispatchesBenchmarksByProvider(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes"}
+	dock := &recordingRuntime{name: "docker"}
+	runtime := &ProviderRuntime{
+		runtimes:  []abstractions.Runtime{kube, dock},
+		providers: map[string]int{"local-provider": 1},
+		fallback:  0,
+	}
+
+	evaluation := evaluationWithBenchmarks("cluster-provider", "local-provider", "other-provider")
+	if err := runtime.RunEvaluationJob(evaluation, nil); err != nil {
+		t.Fatalf("RunEvaluationJob returned error: %v", err)
+	}
+	if strings.Join(kube.benchmarks, ",") != "bench-a,bench-c" {
+		t.Fatalf("expected kubernetes to run bench-a and bench-c, got %v", kube.benchmarks)
+	}
+	if strings.Join(dock.benchmarks, ",") != "bench-b" {
+		t.Fatalf("expected docker to run bench-b, got %v", dock.benchmarks)
+	}
+	if len(evaluation.Benchmarks) != 3 {
+		t.Fatalf("expected the evaluation benchmarks to be left untouched")
+	}
+	if runtime.Name() != "kubernetes,docker" {
+		t.Fatalf("unexpected name %q", runtime.Name())
+	}
+}
+
+func TestProviderRuntimeJoinsRuntimeErrors(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes", err: errors.New("kubernetes failed")}
+	dock := &recordingRuntime{name: "docker", err: errors.New("docker failed")}
+	runtime := &ProviderRuntime{
+		runtimes:  []abstractions.Runtime{kube, dock},
+		providers: map[string]int{"local-provider": 1},
+		fallback:  0,
+	}
+
+	err := runtime.RunEvaluationJob(evaluationWithBenchmarks("cluster-provider", "local-provider"), nil)
+	if err == nil || !strings.Contains(err.Error(), "kubernetes failed") || !strings.Contains(err.Error(), "docker failed") {
+		t.Fatalf("expected both runtime errors, got %v", err)
+	}
+}
+
+func TestProviderRuntimeRejectsProviderWithoutRuntime(t *testing.T) {
+	dock := &recordingRuntime{name: "docker"}
+	runtime := &ProviderRuntime{
+		runtimes:  []abstractions.Runtime{dock},
+		providers: map[string]int{"local-provider": 0},
+		fallback:  -1,
+	}
+
+	err := runtime.RunEvaluationJob(evaluationWithBenchmarks("local-provider", "cluster-provider"), nil)
+	if err == nil || !strings.Contains(err.Error(), "no runtime for provider cluster-provider") {
+		t.Fatalf("expected missing runtime error, got %v", err)
+	}
+	if len(dock.benchmarks) != 0 {
+		t.Fatalf("expected nothing to be submitted, got %v", dock.benchmarks)
+	}
+}
+
+func TestProviderRuntimeRendersOnRuntimeOfProvider(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes"}
+	dock := &recordingRuntime{name: "docker"}
+	runtime := &ProviderRuntime{
+		runtimes:  []abstractions.Runtime{kube, dock},
+		providers: map[string]int{"local-provider": 1},
+		fallback:  0,
+	}
+
+	resources, err := runtime.RenderEvaluationJob(evaluationWithBenchmarks("cluster-provider", "local-provider"))
+	if err != nil {
+		t.Fatalf("RenderEvaluationJob returned error: %v", err)
+	}
+	if len(resources) != 2 || resources[0].Kind != "kubernetes" || resources[0].BenchmarkID != "bench-a" ||
+		resources[1].Kind != "docker" || resources[1].BenchmarkID != "bench-b" {
+		t.Fatalf("unexpected rendered resources %+v", resources)
+	}
+	if len(kube.benchmarks) != 0 || len(dock.benchmarks) != 0 {
+		t.Fatalf("expected nothing to be run")
+	}
+}
+
+func TestProviderRuntimeCancelsOnEveryRuntime(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes"}
+	dock := &recordingRuntime{name: "docker"}
+	runtime := &ProviderRuntime{runtimes: []abstractions.Runtime{kube, dock}}
+
+	if err := runtime.CancelEvaluationJob("job-1"); err != nil {
+		t.Fatalf("CancelEvaluationJob returned error: %v", err)
+	}
+	if len(kube.cancelled) != 1 || len(dock.cancelled) != 1 {
+		t.Fatalf("expected the job to be cancelled on both runtimes")
+	}
+}
+
+func TestProviderRuntimeShutsDownEveryRuntime(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes", err: errors.New("deadline exceeded")}
+	dock := &recordingRuntime{name: "docker"}
+	runtime := &ProviderRuntime{runtimes: []abstractions.Runtime{kube, dock}}
+
+	err := runtime.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Fatalf("expected the error of the kubernetes runtime, got %v", err)
+	}
+	if !kube.shutdown || !dock.shutdown {
+		t.Fatalf("expected both runtimes to be shut down")
+	}
+}
+
+// checkedRuntime is a runtime that depends on another service
+type checkedRuntime struct {
+	*recordingRuntime
+}
+
+func (r *checkedRuntime) ReadinessChecks() []abstractions.ReadinessCheck {
+	return []abstractions.ReadinessCheck{{Name: r.name, Check: func(context.Context) error { return r.err }}}
+}
+
+func TestProviderRuntimeCollectsReadinessChecks(t *testing.T) {
+	kube := &checkedRuntime{&recordingRuntime{name: "kubernetes"}}
+	local := &recordingRuntime{name: "local"}
+	runtime := &ProviderRuntime{runtimes: []abstractions.Runtime{kube, local}}
+
+	checks := runtime.ReadinessChecks()
+	if len(checks) != 1 || checks[0].Name != "kubernetes" {
+		t.Fatalf("expected only the check of the kubernetes runtime, got %+v", checks)
+	}
+}
+
+func TestProviderRuntimeSumsActiveJobs(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes", active: 2}
+	dock := &recordingRuntime{name: "docker", active: 3}
+	runtime := &ProviderRuntime{runtimes: []abstractions.Runtime{kube, dock}}
+
+	count, err := runtime.CountActiveJobs()
+	if err != nil {
+		t.Fatalf("CountActiveJobs returned error: %v", err)
+	}
+	if count != 5 {
+		t.Fatalf("expected 5 active jobs, got %d", count)
+	}
+}
+
+func TestProviderRuntimeStreamsLogsFromRuntimeWithWorkload(t *testing.T) {
+	kube := &recordingRuntime{name: "kubernetes"}
+	dock := &recordingRuntime{name: "docker", logs: "docker logs"}
+	runtime := &ProviderRuntime{runtimes: []abstractions.Runtime{kube, dock}}
+
+	logs, err := runtime.StreamBenchmarkLogs("job-1", "bench-a", abstractions.LogOptions{})
+	if err != nil {
+		t.Fatalf("StreamBenchmarkLogs returned error: %v", err)
+	}
+	content, _ := io.ReadAll(logs)
+	if string(content) != "docker logs" {
+		t.Fatalf("expected the docker logs, got %q", content)
+	}
+
+	_, err = (&ProviderRuntime{runtimes: []abstractions.Runtime{kube}}).StreamBenchmarkLogs("job-1", "bench-a", abstractions.LogOptions{})
+	if !errors.Is(err, abstractions.ErrBenchmarkNotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
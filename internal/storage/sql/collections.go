@@ -1,30 +1,223 @@
 package sql
 
 import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
 	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
+type CollectionEntity struct {
+	Config *api.CollectionConfig `json:"config"`
+}
+
 //#######################################################################
 // Collection operations
 //#######################################################################
 
+// CreateCollection stores the collection in the collections table as a JSON entity.
+// An ID is generated when the collection does not have one, and the resource fields
+// of the collection are filled in from what was stored.
 func (s *SQLStorage) CreateCollection(collection *api.CollectionResource) error {
+	tenant, err := s.getTenant()
+	if err != nil {
+		return err
+	}
+
+	collectionJSON, err := json.Marshal(&CollectionEntity{Config: &collection.CollectionConfig})
+	if err != nil {
+		return err
+	}
+	addEntityStatement, err := createAddEntityStatement(s.sqlConfig.Driver, TABLE_COLLECTIONS)
+	if err != nil {
+		return err
+	}
+	collectionID := collection.Resource.ID
+	if collectionID == "" {
+		collectionID = s.generateID()
+	}
+	s.logger.Info("Creating collection", "id", collectionID, "tenant", tenant)
+	// (id, tenant_id, entity)
+	_, err = s.exec(nil, addEntityStatement, collectionID, tenant, string(collectionJSON))
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return serviceerrors.NewServiceError(messages.ResourceAlreadyExists, "Type", "collection", "ResourceId", collectionID)
+		}
+		s.logger.Error("Failed to create collection", "error", err, "id", collectionID)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "collection", "ResourceId", collectionID, "Error", err.Error())
+	}
+
+	now := time.Now()
+	collection.Resource = api.Resource{
+		ID:        collectionID,
+		Tenant:    tenant,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
 	return nil
 }
 
 func (s *SQLStorage) GetCollection(id string, summary bool) (*api.CollectionResource, error) {
-	return nil, nil
+	selectQuery, err := createGetCollectionStatement(s.sqlConfig.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbID string
+	var createdAt, updatedAt time.Time
+	var tenant string
+	var entityJSON string
+
+	err = s.pool.QueryRowContext(s.ctx, selectQuery, id).Scan(&dbID, &createdAt, &updatedAt, &tenant, &entityJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "collection", "ResourceId", id)
+		}
+		s.logger.Error("Failed to get collection", "error", err, "id", id)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "collection", "ResourceId", id, "Error", err.Error())
+	}
+
+	return constructCollectionResource(dbID, createdAt, updatedAt, tenant, entityJSON, summary)
+}
+
+// constructCollectionResource builds the collection resource from a row of the collections
+// table. A summary leaves out the benchmarks of the collection.
+func constructCollectionResource(dbID string, createdAt time.Time, updatedAt time.Time, tenant string, entityJSON string, summary bool) (*api.CollectionResource, error) {
+	var collectionEntity CollectionEntity
+	if err := json.Unmarshal([]byte(entityJSON), &collectionEntity); err != nil {
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "collection", "Error", err.Error())
+	}
+	collection := &api.CollectionResource{
+		Resource: api.Resource{
+			ID:        dbID,
+			Tenant:    api.Tenant(tenant),
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		},
+	}
+	if collectionEntity.Config != nil {
+		collection.CollectionConfig = *collectionEntity.Config
+	}
+	if summary {
+		collection.Benchmarks = nil
+	}
+	return collection, nil
 }
 
 func (s *SQLStorage) GetCollections(limit int, offset int) (*abstractions.QueryResults[api.CollectionResource], error) {
-	return nil, nil
+	countQuery, countArgs, err := createCountEntitiesStatement(s.sqlConfig.Driver, TABLE_COLLECTIONS)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalCount int
+	err = s.pool.QueryRowContext(s.ctx, countQuery, countArgs...).Scan(&totalCount)
+	if err != nil {
+		s.logger.Error("Failed to count collections", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "collections", "Error", err.Error())
+	}
+
+	listQuery, listArgs, err := createListCollectionsStatement(s.sqlConfig.Driver, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.QueryContext(s.ctx, listQuery, listArgs...)
+	if err != nil {
+		s.logger.Error("Failed to list collections", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "collections", "Error", err.Error())
+	}
+	defer rows.Close()
+
+	items := []api.CollectionResource{}
+	for rows.Next() {
+		var dbID string
+		var createdAt, updatedAt time.Time
+		var tenant string
+		var entityJSON string
+
+		err = rows.Scan(&dbID, &createdAt, &updatedAt, &tenant, &entityJSON)
+		if err != nil {
+			s.logger.Error("Failed to scan collection row", "error", err)
+			return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "collection", "ResourceId", dbID, "Error", err.Error())
+		}
+
+		collection, err := constructCollectionResource(dbID, createdAt, updatedAt, tenant, entityJSON, false)
+		if err != nil {
+			s.logger.Error("Failed to unmarshal collection entity", "error", err, "id", dbID)
+			return nil, err
+		}
+		items = append(items, *collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logger.Error("Error iterating collection rows", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "collections", "Error", err.Error())
+	}
+
+	return &abstractions.QueryResults[api.CollectionResource]{
+		Items:       items,
+		TotalStored: totalCount,
+	}, nil
 }
 
+// UpdateCollection replaces the stored entity of the collection with the given one.
 func (s *SQLStorage) UpdateCollection(collection *api.CollectionResource) error {
+	collectionJSON, err := json.Marshal(&CollectionEntity{Config: &collection.CollectionConfig})
+	if err != nil {
+		return err
+	}
+	updateQuery, err := createUpdateEntityStatement(s.sqlConfig.Driver, TABLE_COLLECTIONS)
+	if err != nil {
+		return err
+	}
+
+	id := collection.Resource.ID
+	result, err := s.exec(nil, updateQuery, string(collectionJSON), id)
+	if err != nil {
+		s.logger.Error("Failed to update collection", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "collection", "ResourceId", id, "Error", err.Error())
+	}
+	if err := checkRowsAffected(result, "collection", id); err != nil {
+		return err
+	}
+
+	collection.Resource.UpdatedAt = time.Now()
+	s.logger.Info("Updated collection", "id", id)
 	return nil
 }
 
 func (s *SQLStorage) DeleteCollection(id string) error {
+	deleteQuery, err := createDeleteEntityStatement(s.sqlConfig.Driver, TABLE_COLLECTIONS)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.exec(nil, deleteQuery, id)
+	if err != nil {
+		s.logger.Error("Failed to delete collection", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "collection", "ResourceId", id, "Error", err.Error())
+	}
+	if err := checkRowsAffected(result, "collection", id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Deleted collection", "id", id)
+	return nil
+}
+
+// checkRowsAffected returns a not found error when the statement did not change any row.
+func checkRowsAffected(result sql.Result, resourceType string, id string) error {
+	count, err := result.RowsAffected()
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", resourceType, "ResourceId", id, "Error", err.Error())
+	}
+	if count == 0 {
+		return serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", resourceType, "ResourceId", id)
+	}
 	return nil
 }
@@ -0,0 +1,126 @@
+package sql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/storage"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func newCollectionStore(t *testing.T) abstractions.Storage {
+	t.Helper()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:collections?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	return store
+}
+
+func sampleCollection(id string) *api.CollectionResource {
+	return &api.CollectionResource{
+		Resource: api.Resource{ID: id},
+		CollectionConfig: api.CollectionConfig{
+			Name: "safety",
+			Benchmarks: []api.BenchmarkConfig{
+				{Ref: api.Ref{ID: "toxicity"}, ProviderID: "garak"},
+			},
+		},
+	}
+}
+
+func expectErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var serviceErr abstractions.ServiceError
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("Expected a service error with code %d, got %v", code, err)
+	}
+	if serviceErr.MessageCode().GetCode() != code {
+		t.Fatalf("Expected code %d, got %d", code, serviceErr.MessageCode().GetCode())
+	}
+}
+
+func TestCollectionLifecycle(t *testing.T) {
+	store := newCollectionStore(t)
+
+	collection := sampleCollection("collection-lifecycle")
+	if err := store.CreateCollection(collection); err != nil {
+		t.Fatalf("Failed to create collection: %v", err)
+	}
+
+	stored, err := store.GetCollection("collection-lifecycle", false)
+	if err != nil {
+		t.Fatalf("Failed to get collection: %v", err)
+	}
+	if stored.Name != "safety" || len(stored.Benchmarks) != 1 || stored.Benchmarks[0].ProviderID != "garak" {
+		t.Fatalf("Unexpected stored collection: %+v", stored)
+	}
+
+	stored.Name = "safety-v2"
+	if err := store.UpdateCollection(stored); err != nil {
+		t.Fatalf("Failed to update collection: %v", err)
+	}
+	updated, err := store.GetCollection("collection-lifecycle", false)
+	if err != nil {
+		t.Fatalf("Failed to get updated collection: %v", err)
+	}
+	if updated.Name != "safety-v2" {
+		t.Fatalf("Expected updated name, got %q", updated.Name)
+	}
+
+	results, err := store.GetCollections(10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list collections: %v", err)
+	}
+	if results.TotalStored < 1 || len(results.Items) < 1 {
+		t.Fatalf("Expected the collection to be listed, got %+v", results)
+	}
+
+	if err := store.DeleteCollection("collection-lifecycle"); err != nil {
+		t.Fatalf("Failed to delete collection: %v", err)
+	}
+	_, err = store.GetCollection("collection-lifecycle", false)
+	expectErrorCode(t, err, constants.HTTPCodeNotFound)
+}
+
+func TestCreateCollectionGeneratesID(t *testing.T) {
+	store := newCollectionStore(t)
+
+	collection := sampleCollection("")
+	if err := store.CreateCollection(collection); err != nil {
+		t.Fatalf("Failed to create collection: %v", err)
+	}
+	if collection.Resource.ID == "" {
+		t.Fatalf("Expected an ID to be generated")
+	}
+	_ = store.DeleteCollection(collection.Resource.ID)
+}
+
+func TestCreateCollectionDuplicateIDConflicts(t *testing.T) {
+	store := newCollectionStore(t)
+
+	if err := store.CreateCollection(sampleCollection("collection-duplicate")); err != nil {
+		t.Fatalf("Failed to create collection: %v", err)
+	}
+	defer func() { _ = store.DeleteCollection("collection-duplicate") }()
+
+	err := store.CreateCollection(sampleCollection("collection-duplicate"))
+	expectErrorCode(t, err, constants.HTTPCodeConflict)
+}
+
+func TestMissingCollectionNotFound(t *testing.T) {
+	store := newCollectionStore(t)
+
+	_, err := store.GetCollection("collection-missing", false)
+	expectErrorCode(t, err, constants.HTTPCodeNotFound)
+	expectErrorCode(t, store.DeleteCollection("collection-missing"), constants.HTTPCodeNotFound)
+	expectErrorCode(t, store.UpdateCollection(sampleCollection("collection-missing")), constants.HTTPCodeNotFound)
+}
@@ -3,6 +3,7 @@ package sql
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"time"
 
 	// import the postgres driver - "pgx"
@@ -20,9 +21,10 @@ import (
 )
 
 type EvaluationJobEntity struct {
-	Config  *api.EvaluationJobConfig  `json:"config"`
-	Status  *api.EvaluationJobStatus  `json:"status"`
-	Results *api.EvaluationJobResults `json:"results,omitempty"`
+	Config    *api.EvaluationJobConfig  `json:"config"`
+	Status    *api.EvaluationJobStatus  `json:"status"`
+	Results   *api.EvaluationJobResults `json:"results,omitempty"`
+	Artifacts []api.ArtifactReference   `json:"artifacts,omitempty"`
 }
 
 //#######################################################################
@@ -33,7 +35,8 @@ type EvaluationJobEntity struct {
 // the evaluation job is stored in the evaluations table as a JSON string
 // the evaluation job is returned as a EvaluationJobResource
 // This should use transactions etc and requires cleaning up
-func (s *SQLStorage) CreateEvaluationJob(evaluation *api.EvaluationJobConfig, mlflowExperimentID string) (*api.EvaluationJobResource, error) {
+// The idempotency key is optional, a key that is already used by the tenant is a conflict
+func (s *SQLStorage) CreateEvaluationJob(evaluation *api.EvaluationJobConfig, mlflowExperimentID string, idempotencyKey string) (*api.EvaluationJobResource, error) {
 	tenant, err := s.getTenant()
 	if err != nil {
 		return nil, err
@@ -61,9 +64,16 @@ func (s *SQLStorage) CreateEvaluationJob(evaluation *api.EvaluationJobConfig, ml
 	}
 	jobID := s.generateID()
 	s.logger.Info("Creating evaluation job", "id", jobID, "tenant", tenant, "status", api.StatePending, "experiment_id", mlflowExperimentID)
-	// (id, tenant_id, status, experiment_id, entity)
-	_, err = s.exec(nil, addEntityStatement, jobID, tenant, api.StatePending, mlflowExperimentID, string(evaluationJSON))
+	key := sql.NullString{String: idempotencyKey, Valid: idempotencyKey != ""}
+	// the stored creation time is the one returned, the list filters compare with it
+	createdAt := s.clock.Now().UTC()
+	created := timestampArg(s.sqlConfig.Driver, createdAt)
+	// (id, created_at, updated_at, tenant_id, status, experiment_id, idempotency_key, entity)
+	_, err = s.exec(nil, addEntityStatement, jobID, created, created, tenant, api.StatePending, mlflowExperimentID, key, string(evaluationJSON))
 	if err != nil {
+		if key.Valid && isDuplicateKeyError(err) {
+			return nil, serviceerrors.NewServiceError(messages.IdempotencyKeyConflict, "IdempotencyKey", idempotencyKey)
+		}
 		return nil, err
 	}
 	evaluationResource := &api.EvaluationJobResource{
@@ -71,8 +81,8 @@ func (s *SQLStorage) CreateEvaluationJob(evaluation *api.EvaluationJobConfig, ml
 			Resource: api.Resource{
 				ID:        jobID,
 				Tenant:    api.Tenant(tenant),
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
 			},
 			MLFlowExperimentID: mlflowExperimentID,
 			Message:            evaluationEntity.Status.Message,
@@ -120,6 +130,43 @@ func (s *SQLStorage) GetEvaluationJob(id string) (*api.EvaluationJobResource, er
 	return evaluationResource, nil
 }
 
+// FindEvaluationJobByIdempotencyKey returns the evaluation job of the tenant that was created
+// with the idempotency key, or nil when there is none.
+func (s *SQLStorage) FindEvaluationJobByIdempotencyKey(idempotencyKey string) (*api.EvaluationJobResource, error) {
+	tenant, err := s.getTenant()
+	if err != nil {
+		return nil, err
+	}
+	selectQuery, err := createFindByIdempotencyKeyStatement(s.sqlConfig.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbID string
+	var createdAt, updatedAt time.Time
+	var statusStr string
+	var experimentID string
+	var entityJSON string
+
+	err = s.pool.QueryRowContext(s.ctx, selectQuery, tenant, idempotencyKey).Scan(&dbID, &createdAt, &updatedAt, &statusStr, &experimentID, &entityJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		s.logger.Error("Failed to find evaluation job by idempotency key", "error", err, "idempotency_key", idempotencyKey)
+		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "evaluation jobs", "Error", err.Error())
+	}
+
+	var evaluationEntity EvaluationJobEntity
+	err = json.Unmarshal([]byte(entityJSON), &evaluationEntity)
+	if err != nil {
+		s.logger.Error("Failed to unmarshal evaluation job entity", "error", err, "id", dbID)
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job", "Error", err.Error())
+	}
+
+	return constructEvaluationResource(statusStr, nil, dbID, createdAt, updatedAt, experimentID, evaluationEntity), nil
+}
+
 func constructEvaluationResource(statusStr string, message *api.MessageInfo, dbID string, createdAt time.Time, updatedAt time.Time, experimentID string, evaluationEntity EvaluationJobEntity) *api.EvaluationJobResource {
 	if message == nil {
 		message = evaluationEntity.Status.Message
@@ -148,13 +195,16 @@ func constructEvaluationResource(statusStr string, message *api.MessageInfo, dbI
 		Status:              status,
 		EvaluationJobConfig: *evaluationEntity.Config,
 		Results:             evaluationEntity.Results,
+		Artifacts:           evaluationEntity.Artifacts,
 	}
 	return evaluationResource
 }
 
+// getEvaluationJobTransactional reads the job and locks it until the end of the transaction,
+// so the concurrent updates of the job are applied one after the other.
 func (s *SQLStorage) getEvaluationJobTransactional(txn *sql.Tx, id string) (*api.EvaluationJobResource, error) {
 	// Build the SELECT query
-	selectQuery, err := createGetEntityStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS)
+	selectQuery, err := createLockEntityStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS)
 	if err != nil {
 		return nil, err
 	}
@@ -189,26 +239,23 @@ func (s *SQLStorage) getEvaluationJobTransactional(txn *sql.Tx, id string) (*api
 	return evaluationResource, nil
 }
 
-func (s *SQLStorage) GetEvaluationJobs(limit int, offset int, statusFilter string) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
-	// Get total count (with status filter if provided)
-	countQuery, countArgs, err := createCountEntitiesStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS, statusFilter)
+// GetEvaluationJobs returns a page of the evaluation jobs that match the filter, TotalStored
+// counts all the matching jobs.
+func (s *SQLStorage) GetEvaluationJobs(limit int, offset int, filter abstractions.EvaluationJobFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	countQuery, countArgs, err := createCountEvaluationsStatement(s.sqlConfig.Driver, filter)
 	if err != nil {
 		return nil, err
 	}
 
 	var totalCount int
-	if len(countArgs) > 0 {
-		err = s.pool.QueryRowContext(s.ctx, countQuery, countArgs...).Scan(&totalCount)
-	} else {
-		err = s.pool.QueryRowContext(s.ctx, countQuery).Scan(&totalCount)
-	}
+	err = s.pool.QueryRowContext(s.ctx, countQuery, countArgs...).Scan(&totalCount)
 	if err != nil {
 		s.logger.Error("Failed to count evaluation jobs", "error", err)
 		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "evaluation jobs", "Error", err.Error())
 	}
 
-	// Build the list query with pagination and status filter
-	listQuery, listArgs, err := createListEntitiesStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS, limit, offset, statusFilter)
+	// Build the list query with pagination and the filter
+	listQuery, listArgs, err := createListEvaluationsStatement(s.sqlConfig.Driver, limit, offset, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -244,25 +291,10 @@ func (s *SQLStorage) GetEvaluationJobs(limit int, offset int, statusFilter strin
 			return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job", "Error", err.Error())
 		}
 
-		// Construct the EvaluationJobResource
-		// Note: Results and Benchmarks are initialized with defaults since they're not stored in the entity column
-		resource := api.EvaluationJobResource{
-			Resource: api.EvaluationResource{
-				Resource: api.Resource{
-					ID:        dbID,
-					Tenant:    "TODO", // TODO: 	retrieve tenant from database or context
-					CreatedAt: createdAt,
-					UpdatedAt: updatedAt,
-				},
-				MLFlowExperimentID: experimentID,
-				Message:            evaluationJobEntity.Status.Message,
-			},
-			Status:              evaluationJobEntity.Status,
-			Results:             evaluationJobEntity.Results,
-			EvaluationJobConfig: *evaluationJobEntity.Config,
-		}
+		// Construct the EvaluationJobResource as GetEvaluationJob does
+		resource := constructEvaluationResource(statusStr, nil, dbID, createdAt, updatedAt, experimentID, evaluationJobEntity)
 
-		items = append(items, resource)
+		items = append(items, *resource)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -276,6 +308,38 @@ func (s *SQLStorage) GetEvaluationJobs(limit int, offset int, statusFilter strin
 	}, nil
 }
 
+// CountEvaluationJobsByStatus counts the evaluation jobs per status with a single grouped query.
+func (s *SQLStorage) CountEvaluationJobsByStatus() (map[api.OverallState]int, error) {
+	countQuery, err := createCountEntitiesByStatusStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.QueryContext(s.ctx, countQuery)
+	if err != nil {
+		s.logger.Error("Failed to count evaluation jobs by status", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "evaluation jobs", "Error", err.Error())
+	}
+	defer rows.Close()
+
+	counts := map[api.OverallState]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			s.logger.Error("Failed to scan evaluation job count", "error", err)
+			return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "evaluation jobs", "Error", err.Error())
+		}
+		counts[api.OverallState(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("Error iterating evaluation job counts", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", "evaluation jobs", "Error", err.Error())
+	}
+
+	return counts, nil
+}
+
 func (s *SQLStorage) DeleteEvaluationJob(id string, hardDelete bool) error {
 	if !hardDelete {
 		return s.UpdateEvaluationJobStatus(id, api.OverallStateCancelled, &api.MessageInfo{
@@ -301,6 +365,8 @@ func (s *SQLStorage) DeleteEvaluationJob(id string, hardDelete bool) error {
 	return nil
 }
 
+// UpdateEvaluationJobStatus runs in a transaction: reads the state of the job and sets the new
+// one, updating a job that does not exist is not an error.
 func (s *SQLStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) error {
 	// Build the UPDATE query
 	updateQuery, err := createUpdateStatusStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS)
@@ -308,19 +374,43 @@ func (s *SQLStorage) UpdateEvaluationJobStatus(id string, state api.OverallState
 		return err
 	}
 
+	txn, err := s.pool.BeginTx(s.ctx, nil)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = txn.Rollback() }()
+
+	job, err := s.getEvaluationJobTransactional(txn, id)
+	if err != nil {
+		var serviceErr *serviceerrors.ServiceError
+		if errors.As(err, &serviceErr) && serviceErr.MessageCode() == messages.ResourceNotFound {
+			return nil
+		}
+		return err
+	}
+	previous := job.Status.State
+
 	// Execute the UPDATE query
-	_, err = s.exec(nil, updateQuery, state, id)
+	_, err = s.exec(txn, updateQuery, state, timestampArg(s.sqlConfig.Driver, s.clock.Now()), id)
 	if err != nil {
 		s.logger.Error("Failed to update evaluation job status", "error", err, "id", id, "status", state)
 		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
 	}
 
+	if err := txn.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+
 	s.logger.Info("Updated evaluation job status", "id", id, "status", state)
+	job.Status.State = state
+	s.observe(previous, job)
 	return nil
 }
 
 func (s *SQLStorage) updateEvaluationJobTransactional(txn *sql.Tx, id string, status api.OverallState, entityJSON string) error {
-	updateQuery, args, err := CreateUpdateEvaluationStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS, id, status, entityJSON)
+	updateQuery, args, err := CreateUpdateEvaluationStatement(s.sqlConfig.Driver, TABLE_EVALUATIONS, id, status, entityJSON, timestampArg(s.sqlConfig.Driver, s.clock.Now()))
 	if err != nil {
 		return err
 	}
@@ -354,9 +444,11 @@ func (s *SQLStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 		return err
 	}
 
+	previous := job.Status.State
 	updateBenchMarkProgress(job, runStatus)
 
 	overallState, message := getOverallJobStatus(job)
+	job.Status.State, job.Status.Message = overallState, message
 
 	updatedEntityJSON, err := json.Marshal(&EvaluationJobEntity{
 		Config: &job.EvaluationJobConfig,
@@ -367,7 +459,8 @@ func (s *SQLStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 			},
 			Benchmarks: job.Status.Benchmarks,
 		},
-		Results: job.Results,
+		Results:   job.Results,
+		Artifacts: job.Artifacts,
 	})
 	if err != nil {
 		s.logger.Error("Failed to marshal updated job resource", "error", err, "id", id)
@@ -381,9 +474,133 @@ func (s *SQLStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 		s.logger.Error("Failed to commit transaction", "error", err, "id", id)
 		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
 	}
+	s.observe(previous, job)
 	return nil
 }
 
+// AddEvaluationJobArtifacts runs in a transaction: fetches the job, merges the artifact
+// references by benchmark and persists the entity without changing the status of the job.
+func (s *SQLStorage) AddEvaluationJobArtifacts(id string, artifacts []api.ArtifactReference) error {
+	txn, err := s.pool.BeginTx(s.ctx, nil)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = txn.Rollback() }()
+
+	job, err := s.getEvaluationJobTransactional(txn, id)
+	if err != nil {
+		return err
+	}
+	job.Artifacts = mergeArtifacts(job.Artifacts, artifacts)
+
+	updatedEntityJSON, err := json.Marshal(&EvaluationJobEntity{
+		Config:    &job.EvaluationJobConfig,
+		Status:    job.Status,
+		Results:   job.Results,
+		Artifacts: job.Artifacts,
+	})
+	if err != nil {
+		s.logger.Error("Failed to marshal updated job resource", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	if err := s.updateEvaluationJobTransactional(txn, id, job.Status.State, string(updatedEntityJSON)); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	return nil
+}
+
+// ResetEvaluationJobBenchmarks runs in a transaction: fetches the job, sets the statuses of
+// the benchmarks back to pending, drops their results and persists the entity with the
+// overall state of the job computed again. The other benchmarks are left as they are.
+func (s *SQLStorage) ResetEvaluationJobBenchmarks(id string, benchmarkIDs []string) error {
+	txn, err := s.pool.BeginTx(s.ctx, nil)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = txn.Rollback() }()
+
+	job, err := s.getEvaluationJobTransactional(txn, id)
+	if err != nil {
+		return err
+	}
+	previous := job.Status.State
+	reset := make(map[string]bool, len(benchmarkIDs))
+	for _, benchmarkID := range benchmarkIDs {
+		reset[benchmarkID] = true
+	}
+	for i := range job.Status.Benchmarks {
+		status := &job.Status.Benchmarks[i]
+		if reset[status.ID] {
+			*status = api.BenchmarkStatus{ProviderID: status.ProviderID, ID: status.ID, Status: api.StatePending}
+		}
+	}
+	if job.Results != nil {
+		kept := job.Results.Benchmarks[:0]
+		for _, result := range job.Results.Benchmarks {
+			if !reset[result.ID] {
+				kept = append(kept, result)
+			}
+		}
+		job.Results.Benchmarks = kept
+	}
+
+	overallState, message := getOverallJobStatus(job)
+	job.Status.State, job.Status.Message = overallState, message
+
+	updatedEntityJSON, err := json.Marshal(&EvaluationJobEntity{
+		Config: &job.EvaluationJobConfig,
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{
+				State:   overallState,
+				Message: message,
+			},
+			Benchmarks: job.Status.Benchmarks,
+		},
+		Results:   job.Results,
+		Artifacts: job.Artifacts,
+	})
+	if err != nil {
+		s.logger.Error("Failed to marshal updated job resource", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	if err := s.updateEvaluationJobTransactional(txn, id, overallState, string(updatedEntityJSON)); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", "error", err, "id", id)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error())
+	}
+	s.observe(previous, job)
+	return nil
+}
+
+// mergeArtifacts replaces the stored reference of a benchmark with the added one and appends
+// the references of the other benchmarks.
+func mergeArtifacts(stored []api.ArtifactReference, added []api.ArtifactReference) []api.ArtifactReference {
+	for _, artifact := range added {
+		replaced := false
+		for i := range stored {
+			if stored[i].BenchmarkID == artifact.BenchmarkID {
+				stored[i] = artifact
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			stored = append(stored, artifact)
+		}
+	}
+	return stored
+}
+
 func validateBenchmarkExists(job *api.EvaluationJobResource, runStatus *api.StatusEvent) error {
 	found := false
 	for _, benchmark := range job.Benchmarks {
@@ -412,6 +629,8 @@ func getOverallJobStatus(job *api.EvaluationJobResource) (api.OverallState, *api
 	// determine the overall job status
 	total := len(job.Benchmarks)
 	completed, failed, running := benchmarkStates[api.StateCompleted], benchmarkStates[api.StateFailed], benchmarkStates[api.StateRunning]
+	// a cancelled benchmark is finished without counting as a failure
+	cancelled := benchmarkStates[api.StateCancelled]
 
 	var overallState api.OverallState
 	var stateMessage string
@@ -420,7 +639,11 @@ func getOverallJobStatus(job *api.EvaluationJobResource) (api.OverallState, *api
 		overallState, stateMessage = api.OverallStateCompleted, "Evaluation job is completed"
 	case failed == total:
 		overallState, stateMessage = api.OverallStateFailed, "Evaluation job is failed. \n"+failureMessage
-	case completed+failed == total:
+	case cancelled == total:
+		overallState, stateMessage = api.OverallStateCancelled, "Evaluation job is cancelled"
+	case completed+failed+cancelled == total && failed == 0:
+		overallState, stateMessage = api.OverallStateCompleted, "Evaluation job is completed, some of the benchmarks were cancelled"
+	case completed+failed+cancelled == total:
 		overallState, stateMessage = api.OverallStatePartiallyFailed, "Some of the benchmarks failed. \n"+failureMessage
 	case running > 0:
 		overallState, stateMessage = api.OverallStateRunning, "Evaluation job is running"
@@ -438,6 +661,12 @@ func updateBenchMarkProgress(jobResource *api.EvaluationJobResource, runStatus *
 	if jobResource.Results == nil {
 		jobResource.Results = &api.EvaluationJobResults{}
 	}
+	// the adapter of a cancelled benchmark may still report while it is stopped
+	for _, status := range jobResource.Status.Benchmarks {
+		if status.ID == runStatus.BenchmarkStatusEvent.ID && status.Status == api.StateCancelled {
+			return
+		}
+	}
 	jobResource.Status.Benchmarks = findAndUpdateBenchmarkStatus(jobResource.Status.Benchmarks, runStatus)
 	findAndUpdateBenchmarkResults(jobResource.Results, runStatus)
 }
@@ -447,12 +676,18 @@ func findAndUpdateBenchmarkStatus(benchmarkStatus []api.BenchmarkStatus, runStat
 	for i := range benchmarkStatus {
 		status := &benchmarkStatus[i]
 		if status.ID == runStatus.BenchmarkStatusEvent.ID {
+			found = true
 			prevStatus := status.Status
+			// the runtime records a submitted benchmark as pending, which must not undo a
+			// status the adapter reported in the meantime
+			if runStatus.BenchmarkStatusEvent.Status == api.StatePending && prevStatus != api.StatePending {
+				break
+			}
 			status.Status = runStatus.BenchmarkStatusEvent.Status
 			if prevStatus == api.StatePending && runStatus.BenchmarkStatusEvent.Status == api.StateRunning {
 				status.StartedAt = runStatus.BenchmarkStatusEvent.StartedAt
 			}
-			if runStatus.BenchmarkStatusEvent.Status == api.StateCompleted {
+			if runStatus.BenchmarkStatusEvent.Status == api.StateCompleted && runStatus.BenchmarkStatusEvent.CompletedAt != nil {
 				status.CompletedAt = runStatus.BenchmarkStatusEvent.CompletedAt
 			}
 			if runStatus.BenchmarkStatusEvent.Status == api.StateFailed && runStatus.BenchmarkStatusEvent.ErrorMessage != nil {
@@ -461,7 +696,9 @@ func findAndUpdateBenchmarkStatus(benchmarkStatus []api.BenchmarkStatus, runStat
 					MessageCode: runStatus.BenchmarkStatusEvent.ErrorMessage.MessageCode,
 				}
 			}
-			found = true
+			if runStatus.BenchmarkStatusEvent.Message != nil {
+				status.Message = runStatus.BenchmarkStatusEvent.Message
+			}
 			break
 		}
 	}
@@ -471,6 +708,7 @@ func findAndUpdateBenchmarkStatus(benchmarkStatus []api.BenchmarkStatus, runStat
 			ProviderID: runStatus.BenchmarkStatusEvent.ProviderID,
 			ID:         runStatus.BenchmarkStatusEvent.ID,
 			Status:     runStatus.BenchmarkStatusEvent.Status,
+			Message:    runStatus.BenchmarkStatusEvent.Message,
 		}
 		if runStatus.BenchmarkStatusEvent.Status == api.StateFailed && runStatus.BenchmarkStatusEvent.ErrorMessage != nil {
 			newBenchmarkStatus.ErrorMessage = &api.MessageInfo{
@@ -492,8 +730,10 @@ func findAndUpdateBenchmarkResults(benchmarkResults *api.EvaluationJobResults, r
 		result := &benchmarkResults.Benchmarks[i]
 		if result.ID == runStatus.BenchmarkStatusEvent.ID {
 			if runStatus.BenchmarkStatusEvent.Status == api.StateCompleted {
-				result.Metrics = runStatus.BenchmarkStatusEvent.Metrics
-				result.Artifacts = runStatus.BenchmarkStatusEvent.Artifacts
+				// the adapter and the result collection of the runtime can both report the
+				// metrics, so they are merged rather than replaced
+				result.Metrics = mergeValues(result.Metrics, runStatus.BenchmarkStatusEvent.Metrics)
+				result.Artifacts = mergeValues(result.Artifacts, runStatus.BenchmarkStatusEvent.Artifacts)
 			}
 			found = true
 			break
@@ -511,3 +751,18 @@ func findAndUpdateBenchmarkResults(benchmarkResults *api.EvaluationJobResults, r
 		benchmarkResults.Benchmarks = append(benchmarkResults.Benchmarks, newBenchmarkResult)
 	}
 }
+
+// mergeValues adds the updated values to the stored ones, an updated value replaces the
+// stored value with the same key.
+func mergeValues(stored map[string]any, updated map[string]any) map[string]any {
+	if len(updated) == 0 {
+		return stored
+	}
+	if stored == nil {
+		stored = make(map[string]any, len(updated))
+	}
+	for key, value := range updated {
+		stored[key] = value
+	}
+	return stored
+}
@@ -1,11 +1,18 @@
 package sql_test
 
 import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
 	"github.com/eval-hub/eval-hub/internal/logging"
 	"github.com/eval-hub/eval-hub/internal/storage"
+	evalsql "github.com/eval-hub/eval-hub/internal/storage/sql"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
@@ -44,7 +51,7 @@ func TestUpdateEvaluationJob_PreservesProviderID(t *testing.T) {
 		},
 	}
 
-	job, err := store.CreateEvaluationJob(config, "")
+	job, err := store.CreateEvaluationJob(config, "", "")
 	if err != nil {
 		t.Fatalf("Failed to create job: %v", err)
 	}
@@ -128,3 +135,692 @@ func TestUpdateEvaluationJob_PreservesProviderID(t *testing.T) {
 		t.Errorf("Expected acc=0.85, got %v", result.Metrics["acc"])
 	}
 }
+
+func TestCountEvaluationJobsByStatus(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:status_counts?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config := &api.EvaluationJobConfig{
+		Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+	}
+	var ids []string
+	for range 3 {
+		job, err := store.CreateEvaluationJob(config, "", "")
+		if err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		ids = append(ids, job.Resource.ID)
+	}
+	if err := store.UpdateEvaluationJobStatus(ids[0], api.OverallStateFailed, nil); err != nil {
+		t.Fatalf("Failed to update job status: %v", err)
+	}
+
+	counts, err := store.CountEvaluationJobsByStatus()
+	if err != nil {
+		t.Fatalf("Failed to count jobs: %v", err)
+	}
+	if counts[api.OverallStatePending] != 2 || counts[api.OverallStateFailed] != 1 {
+		t.Fatalf("Unexpected job counts: %v", counts)
+	}
+}
+
+func TestStatusIndexIsAddedToExistingTables(t *testing.T) {
+	url := "file:status_index?mode=memory&cache=shared"
+	// keep a connection open so that the in-memory database outlives the setup
+	db, err := sql.Open("sqlite", url)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	// the evaluations table as it was created before the status index
+	_, err = db.Exec(`CREATE TABLE evaluations (
+		id VARCHAR(36) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		tenant_id VARCHAR(255) NOT NULL,
+		status VARCHAR(50) NOT NULL DEFAULT 'pending',
+		experiment_id VARCHAR(255) NOT NULL,
+		entity TEXT NOT NULL,
+		PRIMARY KEY (id)
+	);`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           url,
+		"database_name": "eval_hub",
+	}
+	if _, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger()); err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	rows, err := db.Query(`EXPLAIN QUERY PLAN SELECT id, created_at, updated_at, status, experiment_id, entity FROM "evaluations" WHERE status = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;`, "running", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to explain query: %v", err)
+	}
+	defer rows.Close()
+	var plan []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			t.Fatalf("Failed to scan query plan: %v", err)
+		}
+		plan = append(plan, detail)
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "idx_evaluations_status_created_at") || strings.Contains(strings.Join(plan, "\n"), "TEMP B-TREE") {
+		t.Fatalf("Expected the status filter to read the jobs in order from idx_evaluations_status_created_at, got plan %v", plan)
+	}
+}
+
+func TestFindEvaluationJobByIdempotencyKey(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:idempotency_keys?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config := &api.EvaluationJobConfig{
+		Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+	}
+	job, err := store.CreateEvaluationJob(config, "", "key-1")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	// jobs without a key never conflict
+	for range 2 {
+		if _, err := store.CreateEvaluationJob(config, "", ""); err != nil {
+			t.Fatalf("Failed to create job without a key: %v", err)
+		}
+	}
+
+	found, err := store.FindEvaluationJobByIdempotencyKey("key-1")
+	if err != nil {
+		t.Fatalf("Failed to find job: %v", err)
+	}
+	if found == nil || found.Resource.ID != job.Resource.ID || found.Model.Name != "test-model" {
+		t.Fatalf("Expected job %s to be found, got %+v", job.Resource.ID, found)
+	}
+
+	missing, err := store.FindEvaluationJobByIdempotencyKey("key-2")
+	if err != nil || missing != nil {
+		t.Fatalf("Expected no job for an unused key, got %+v, %v", missing, err)
+	}
+
+	_, err = store.CreateEvaluationJob(config, "", "key-1")
+	expectErrorCode(t, err, constants.HTTPCodeConflict)
+}
+
+func TestAddEvaluationJobArtifacts(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:artifacts?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config := &api.EvaluationJobConfig{
+		Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{
+			{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+			{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"},
+		},
+	}
+	job, err := store.CreateEvaluationJob(config, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	first := api.ArtifactReference{BenchmarkID: "arc_easy", ProviderID: "lm_evaluation_harness", URI: "s3://bucket/old/"}
+	if err := store.AddEvaluationJobArtifacts(job.Resource.ID, []api.ArtifactReference{first}); err != nil {
+		t.Fatalf("Failed to add artifacts: %v", err)
+	}
+	replaced := api.ArtifactReference{BenchmarkID: "arc_easy", ProviderID: "lm_evaluation_harness", URI: "s3://bucket/arc_easy/"}
+	second := api.ArtifactReference{BenchmarkID: "mmlu", ProviderID: "lm_evaluation_harness", URI: "s3://bucket/mmlu/"}
+	if err := store.AddEvaluationJobArtifacts(job.Resource.ID, []api.ArtifactReference{replaced, second}); err != nil {
+		t.Fatalf("Failed to add artifacts: %v", err)
+	}
+
+	// a status update keeps the artifacts
+	err = store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{ProviderID: "lm_evaluation_harness", ID: "mmlu", Status: api.StateRunning},
+	})
+	if err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+
+	stored, err := store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if len(stored.Artifacts) != 2 || stored.Artifacts[0] != replaced || stored.Artifacts[1] != second {
+		t.Fatalf("Unexpected artifacts %+v", stored.Artifacts)
+	}
+	if stored.Status.State != api.OverallStateRunning {
+		t.Fatalf("Expected the job to be running, got %s", stored.Status.State)
+	}
+
+	// the list returns the artifacts as the get does
+	listed, err := store.GetEvaluationJobs(10, 0, abstractions.EvaluationJobFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+	if len(listed.Items) != 1 || len(listed.Items[0].Artifacts) != 2 || listed.Items[0].Artifacts[1] != second {
+		t.Fatalf("Expected the listed job to have its artifacts, got %+v", listed.Items)
+	}
+
+	err = store.AddEvaluationJobArtifacts("missing-job", []api.ArtifactReference{first})
+	expectErrorCode(t, err, constants.HTTPCodeNotFound)
+}
+
+func TestUpdateEvaluationJobMergesMetrics(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:metrics?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config := &api.EvaluationJobConfig{
+		Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{
+			{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+		},
+	}
+	job, err := store.CreateEvaluationJob(config, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	updates := []*api.BenchmarkStatusEvent{
+		{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: api.StateCompleted, Metrics: map[string]any{"acc": 0.5}},
+		{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: api.StateCompleted, Metrics: map[string]any{"acc": 0.8, "acc_norm": 0.9}},
+		// a completion without metrics keeps the stored ones
+		{
+			ProviderID: "lm_evaluation_harness",
+			ID:         "arc_easy",
+			Status:     api.StateCompleted,
+			Message:    &api.MessageInfo{Message: "benchmark completed without metrics", MessageCode: constants.MESSAGE_CODE_BENCHMARK_NO_METRICS},
+		},
+	}
+	for _, update := range updates {
+		if err := store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{BenchmarkStatusEvent: update}); err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+	}
+
+	stored, err := store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if stored.Results == nil || len(stored.Results.Benchmarks) != 1 {
+		t.Fatalf("Expected the results of one benchmark, got %+v", stored.Results)
+	}
+	metrics := stored.Results.Benchmarks[0].Metrics
+	if len(metrics) != 2 || metrics["acc"] != 0.8 || metrics["acc_norm"] != 0.9 {
+		t.Fatalf("Unexpected metrics %+v", metrics)
+	}
+	if len(stored.Status.Benchmarks) != 1 || stored.Status.Benchmarks[0].Message == nil ||
+		stored.Status.Benchmarks[0].Message.MessageCode != constants.MESSAGE_CODE_BENCHMARK_NO_METRICS {
+		t.Fatalf("Expected the benchmark status to explain the missing metrics, got %+v", stored.Status.Benchmarks)
+	}
+}
+
+func TestPendingBenchmarkEventDoesNotUndoProgress(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:pending?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+		Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+	}, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// the adapter reports the benchmark running before the runtime recorded its submission
+	for _, state := range []api.State{api.StateRunning, api.StatePending} {
+		err := store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: state},
+		})
+		if err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+	}
+
+	stored, err := store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if len(stored.Status.Benchmarks) != 1 || stored.Status.Benchmarks[0].Status != api.StateRunning {
+		t.Fatalf("Expected the benchmark to stay running, got %+v", stored.Status.Benchmarks)
+	}
+	if stored.Status.State != api.OverallStateRunning {
+		t.Fatalf("Expected the job to stay running, got %s", stored.Status.State)
+	}
+}
+
+func TestCancelledBenchmarkIsNotCountedAsFailed(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:cancelled?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+		Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{
+			{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+			{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"},
+		},
+	}, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// the adapter of the cancelled benchmark reports a failure once its pod is stopped
+	updates := []*api.BenchmarkStatusEvent{
+		{ProviderID: "lm_evaluation_harness", ID: "mmlu", Status: api.StateRunning},
+		{ProviderID: "lm_evaluation_harness", ID: "mmlu", Status: api.StateCancelled},
+		{
+			ProviderID:   "lm_evaluation_harness",
+			ID:           "mmlu",
+			Status:       api.StateFailed,
+			ErrorMessage: &api.MessageInfo{Message: "terminated", MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED},
+		},
+	}
+	for _, update := range updates {
+		if err := store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{BenchmarkStatusEvent: update}); err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+	}
+	stored, err := store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if len(stored.Status.Benchmarks) != 1 || stored.Status.Benchmarks[0].Status != api.StateCancelled || stored.Status.Benchmarks[0].ErrorMessage != nil {
+		t.Fatalf("Expected the benchmark to stay cancelled, got %+v", stored.Status.Benchmarks)
+	}
+	if stored.Status.State != api.OverallStatePending {
+		t.Fatalf("Expected the job to wait for arc_easy, got %s", stored.Status.State)
+	}
+
+	err = store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: api.StateCompleted},
+	})
+	if err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+	stored, err = store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if stored.Status.State != api.OverallStateCompleted {
+		t.Fatalf("Expected the job to be completed, got %s", stored.Status.State)
+	}
+}
+
+func TestResetEvaluationJobBenchmarks(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:reset?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config := &api.EvaluationJobConfig{
+		Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{
+			{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+			{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"},
+		},
+	}
+	job, err := store.CreateEvaluationJob(config, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	updates := []*api.BenchmarkStatusEvent{
+		{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: api.StateCompleted, Metrics: map[string]any{"acc": 0.8}},
+		{
+			ProviderID:   "lm_evaluation_harness",
+			ID:           "mmlu",
+			Status:       api.StateFailed,
+			ErrorMessage: &api.MessageInfo{Message: "out of memory", MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED},
+		},
+	}
+	for _, update := range updates {
+		if err := store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{BenchmarkStatusEvent: update}); err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+	}
+
+	if err := store.ResetEvaluationJobBenchmarks(job.Resource.ID, []string{"mmlu"}); err != nil {
+		t.Fatalf("Failed to reset benchmarks: %v", err)
+	}
+
+	stored, err := store.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if stored.Status.State != api.OverallStatePending {
+		t.Fatalf("Expected the job to be pending again, got %s", stored.Status.State)
+	}
+	for _, status := range stored.Status.Benchmarks {
+		switch status.ID {
+		case "arc_easy":
+			if status.Status != api.StateCompleted {
+				t.Fatalf("Expected arc_easy to stay completed, got %s", status.Status)
+			}
+		case "mmlu":
+			if status.Status != api.StatePending || status.ErrorMessage != nil {
+				t.Fatalf("Expected mmlu to be reset, got %+v", status)
+			}
+		}
+	}
+	if len(stored.Results.Benchmarks) != 1 || stored.Results.Benchmarks[0].ID != "arc_easy" || stored.Results.Benchmarks[0].Metrics["acc"] != 0.8 {
+		t.Fatalf("Expected only the results of arc_easy to be kept, got %+v", stored.Results.Benchmarks)
+	}
+
+	err = store.ResetEvaluationJobBenchmarks("missing-job", []string{"mmlu"})
+	expectErrorCode(t, err, constants.HTTPCodeNotFound)
+}
+
+func TestCanceledContextAbortsEvaluationJobWrites(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:canceled_context?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	config := &api.EvaluationJobConfig{
+		Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+	}
+	job, err := store.CreateEvaluationJob(config, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := store.WithContext(ctx)
+
+	if _, err := canceled.CreateEvaluationJob(config, "", "key-canceled"); err == nil {
+		t.Fatalf("Expected the create to be aborted")
+	}
+	if found, err := store.FindEvaluationJobByIdempotencyKey("key-canceled"); err != nil || found != nil {
+		t.Fatalf("Expected no job to be stored, got %+v, %v", found, err)
+	}
+
+	if err := canceled.DeleteEvaluationJob(job.Resource.ID, true); err == nil {
+		t.Fatalf("Expected the delete to be aborted")
+	}
+	if _, err := store.GetEvaluationJob(job.Resource.ID); err != nil {
+		t.Fatalf("Expected the job to be kept, got %v", err)
+	}
+}
+
+func TestGetEvaluationJobsFilters(t *testing.T) {
+	url := "file:evaluation_filters?mode=memory&cache=shared"
+	// keep a connection open to backdate a job and to explain the queries
+	db, err := sql.Open("sqlite", url)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           url,
+		"database_name": "eval_hub",
+	}
+	store, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	createJob := func(modelName string) *api.EvaluationJobResource {
+		job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: modelName},
+			Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		}, "", "")
+		if err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		return job
+	}
+	oldJob := createJob("model-a")
+	newJob := createJob("model-a")
+	otherJob := createJob("model-b")
+	if _, err := db.Exec(`UPDATE evaluations SET created_at = '2025-01-01 00:00:00' WHERE id = ?;`, oldJob.Resource.ID); err != nil {
+		t.Fatalf("Failed to backdate job: %v", err)
+	}
+	if err := store.UpdateEvaluationJobStatus(otherJob.Resource.ID, api.OverallStateFailed, nil); err != nil {
+		t.Fatalf("Failed to update job status: %v", err)
+	}
+
+	stored, err := store.GetEvaluationJob(newJob.Resource.ID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if !stored.Resource.CreatedAt.Equal(newJob.Resource.CreatedAt) {
+		t.Fatalf("Expected the creation time %v to be stored, got %v", newJob.Resource.CreatedAt, stored.Resource.CreatedAt)
+	}
+
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	justBefore := newJob.Resource.CreatedAt.Add(-time.Millisecond)
+	tests := []struct {
+		name     string
+		filter   abstractions.EvaluationJobFilter
+		expected []string
+	}{
+		{"model name", abstractions.EvaluationJobFilter{ModelName: "model-a"}, []string{oldJob.Resource.ID, newJob.Resource.ID}},
+		{"created after", abstractions.EvaluationJobFilter{CreatedAfter: &cutoff}, []string{newJob.Resource.ID, otherJob.Resource.ID}},
+		{"created before", abstractions.EvaluationJobFilter{CreatedBefore: &cutoff}, []string{oldJob.Resource.ID}},
+		{"sub-second bound", abstractions.EvaluationJobFilter{ModelName: "model-a", CreatedAfter: &justBefore}, []string{newJob.Resource.ID}},
+		{"model name and time window", abstractions.EvaluationJobFilter{ModelName: "model-a", CreatedAfter: &cutoff}, []string{newJob.Resource.ID}},
+		{"status and model name", abstractions.EvaluationJobFilter{Status: string(api.OverallStateFailed), ModelName: "model-b"}, []string{otherJob.Resource.ID}},
+		{"no match", abstractions.EvaluationJobFilter{Status: string(api.OverallStateFailed), ModelName: "model-a"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := store.GetEvaluationJobs(10, 0, test.filter)
+			if err != nil {
+				t.Fatalf("Failed to list jobs: %v", err)
+			}
+			var ids []string
+			for _, item := range res.Items {
+				ids = append(ids, item.Resource.ID)
+			}
+			slices.Sort(ids)
+			expected := slices.Clone(test.expected)
+			slices.Sort(expected)
+			if !slices.Equal(ids, expected) || res.TotalStored != len(expected) {
+				t.Fatalf("Expected jobs %v, got %v with a total of %d", expected, ids, res.TotalStored)
+			}
+		})
+	}
+
+	for index, condition := range map[string]string{
+		"idx_evaluations_model_name_created_at": "model_name = ?",
+		"idx_evaluations_created_at":            "created_at > ?",
+	} {
+		rows, err := db.Query(`EXPLAIN QUERY PLAN SELECT COUNT(*) FROM "evaluations" WHERE `+condition+`;`, "x")
+		if err != nil {
+			t.Fatalf("Failed to explain query: %v", err)
+		}
+		var plan []string
+		for rows.Next() {
+			var id, parent, notUsed int
+			var detail string
+			if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+				t.Fatalf("Failed to scan query plan: %v", err)
+			}
+			plan = append(plan, detail)
+		}
+		rows.Close()
+		if !strings.Contains(strings.Join(plan, "\n"), index) {
+			t.Fatalf("Expected %s to use %s, got plan %v", condition, index, plan)
+		}
+	}
+}
+
+func TestGetEvaluationJobsAfterCursor(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:evaluation_keyset?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	clock := &fakeClock{}
+	store, err := evalsql.NewStorage(databaseConfig, logging.FallbackLogger(), clock)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	// the jobs created at the same time are ordered by their IDs
+	start := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	var jobs []abstractions.EvaluationJobCursor
+	for _, minutes := range []int{0, 2, 1, 1, 3, 1} {
+		clock.now = start.Add(time.Duration(minutes) * time.Minute)
+		job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "model-a"},
+			Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		}, "", "")
+		if err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		jobs = append(jobs, abstractions.EvaluationJobCursor{CreatedAt: job.Resource.CreatedAt, ID: job.Resource.ID})
+	}
+	slices.SortFunc(jobs, func(a, b abstractions.EvaluationJobCursor) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+
+	// every page starts after the last job of the previous one
+	var listed []string
+	filter := abstractions.EvaluationJobFilter{ModelName: "model-a"}
+	for {
+		res, err := store.GetEvaluationJobs(2, 0, filter)
+		if err != nil {
+			t.Fatalf("Failed to list jobs: %v", err)
+		}
+		if res.TotalStored != len(ids) {
+			t.Fatalf("Expected the total to ignore the cursor, got %d", res.TotalStored)
+		}
+		if len(res.Items) == 0 {
+			break
+		}
+		for _, item := range res.Items {
+			listed = append(listed, item.Resource.ID)
+		}
+		last := res.Items[len(res.Items)-1].Resource
+		filter.After = &abstractions.EvaluationJobCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	if !slices.Equal(listed, ids) {
+		t.Fatalf("Expected the newest jobs first %v, got %v", ids, listed)
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestEvaluationJobTimestampsFollowTheClock(t *testing.T) {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:evaluation_timestamps?mode=memory&cache=shared",
+		"database_name": "eval_hub",
+	}
+	created := time.Date(2026, 3, 1, 9, 30, 0, 250000000, time.UTC)
+	clock := &fakeClock{now: created}
+	store, err := evalsql.NewStorage(databaseConfig, logging.FallbackLogger(), clock)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	job, err := store.CreateEvaluationJob(&api.EvaluationJobConfig{
+		Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+		Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+	}, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if !job.Resource.CreatedAt.Equal(created) || !job.Resource.UpdatedAt.Equal(created) {
+		t.Fatalf("Expected the job to be stamped with %v, got %v and %v", created, job.Resource.CreatedAt, job.Resource.UpdatedAt)
+	}
+
+	expectTimestamps := func(updated time.Time) {
+		t.Helper()
+		stored, err := store.GetEvaluationJob(job.Resource.ID)
+		if err != nil {
+			t.Fatalf("Failed to get job: %v", err)
+		}
+		listed, err := store.GetEvaluationJobs(10, 0, abstractions.EvaluationJobFilter{})
+		if err != nil || len(listed.Items) != 1 {
+			t.Fatalf("Failed to list jobs: %v", err)
+		}
+		for _, resource := range []api.Resource{stored.Resource.Resource, listed.Items[0].Resource.Resource} {
+			if !resource.CreatedAt.Equal(created) || !resource.UpdatedAt.Equal(updated) {
+				t.Fatalf("Expected created at %v and updated at %v, got %v and %v", created, updated, resource.CreatedAt, resource.UpdatedAt)
+			}
+		}
+	}
+	expectTimestamps(created)
+
+	clock.now = created.Add(time.Minute)
+	err = store.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{ProviderID: "lm_evaluation_harness", ID: "arc_easy", Status: api.StateRunning},
+	})
+	if err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+	expectTimestamps(clock.now)
+
+	clock.now = created.Add(time.Hour)
+	if err := store.UpdateEvaluationJobStatus(job.Resource.ID, api.OverallStateCancelled, nil); err != nil {
+		t.Fatalf("Failed to update job status: %v", err)
+	}
+	expectTimestamps(clock.now)
+}
@@ -1,35 +1,56 @@
 package sql
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/eval-hub/eval-hub/internal/abstractions"
 	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // TODO - do we want to pull out all the SQL statements like this or leave them in the functions?
 
 // SQLite: use ? placeholders
-const SQLITE_INSERT_EVALUATION_STATEMENT = `INSERT INTO evaluations (id, tenant_id, status, experiment_id, entity) VALUES (?, ?, ?, ?, ?);`
+const SQLITE_INSERT_EVALUATION_STATEMENT = `INSERT INTO evaluations (id, created_at, updated_at, tenant_id, status, experiment_id, idempotency_key, entity) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
 
 // PostgreSQL: use $1, $2 placeholders and RETURNING id clause
-const POSTGRES_INSERT_EVALUATION_STATEMENT = `INSERT INTO evaluations (id, tenant_id, status, experiment_id, entity) VALUES ($1, $2, $3, $4, $5) RETURNING id;`
+const POSTGRES_INSERT_EVALUATION_STATEMENT = `INSERT INTO evaluations (id, created_at, updated_at, tenant_id, status, experiment_id, idempotency_key, entity) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
 
-// TODO: Add collection insert statement
-const INSERT_COLLECTION_STATEMENT = `INSERT INTO collections (entity) VALUES (?);`
+// SQLITE_TIMESTAMP_FORMAT extends the format of CURRENT_TIMESTAMP with the fractional seconds
+const SQLITE_TIMESTAMP_FORMAT = "2006-01-02 15:04:05.999999999"
+
+// SQLite: use ? placeholders
+const SQLITE_INSERT_COLLECTION_STATEMENT = `INSERT INTO collections (id, tenant_id, entity) VALUES (?, ?, ?);`
+
+// PostgreSQL: use $1, $2 placeholders and RETURNING id clause
+const POSTGRES_INSERT_COLLECTION_STATEMENT = `INSERT INTO collections (id, tenant_id, entity) VALUES ($1, $2, $3) RETURNING id;`
 
 func getUnsupportedDriverError(driver string) error {
 	return fmt.Errorf("unsupported driver: %s", driver)
 }
 
-func schemasForDriver(driver string) (string, error) {
+func migrationForDriver(migration schemaMigration, driver string) (string, error) {
+	statements, ok := migration.statements[driver]
+	if !ok {
+		return "", getUnsupportedDriverError(driver)
+	}
+	// better to be safe than sorry
+	return strings.ReplaceAll(statements, "'pending'", "'"+string(api.StatePending)+"'"), nil
+}
+
+// createInsertMigrationStatement returns a driver-specific INSERT statement
+// that records an applied schema migration
+func createInsertMigrationStatement(driver string) (string, error) {
 	switch driver {
-	case SQLITE_DRIVER:
-		// better to be safe than sorry
-		return strings.ReplaceAll(SQLITE_SCHEMA, "pending", string(api.StatePending)), nil
 	case POSTGRES_DRIVER:
-		// better to be safe than sorry
-		return strings.ReplaceAll(POSTGRES_SCHEMA, "pending", string(api.StatePending)), nil
+		return `INSERT INTO schema_migrations (version, description) VALUES ($1, $2);`, nil
+	case SQLITE_DRIVER:
+		return `INSERT INTO schema_migrations (version, description) VALUES (?, ?);`, nil
 	default:
 		return "", getUnsupportedDriverError(driver)
 	}
@@ -44,11 +65,28 @@ func createAddEntityStatement(driver, tableName string) (string, error) {
 	case SQLITE_DRIVER + TABLE_EVALUATIONS:
 		// SQLite: use ? placeholders
 		return SQLITE_INSERT_EVALUATION_STATEMENT, nil
+	case POSTGRES_DRIVER + TABLE_COLLECTIONS:
+		return POSTGRES_INSERT_COLLECTION_STATEMENT, nil
+	case SQLITE_DRIVER + TABLE_COLLECTIONS:
+		return SQLITE_INSERT_COLLECTION_STATEMENT, nil
 	default:
 		return "", getUnsupportedDriverError(driver)
 	}
 }
 
+// isDuplicateKeyError reports whether the error is a primary key or unique constraint violation
+func isDuplicateKeyError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505" // unique_violation
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+	return false
+}
+
 // quoteIdentifier properly quotes an identifier for the given driver
 func quoteIdentifier(_ /*driver*/ string, identifier string) string {
 	// Escape double quotes by doubling them
@@ -72,6 +110,82 @@ func createGetEntityStatement(driver, tableName string) (string, error) {
 	}
 }
 
+// createLockEntityStatement returns a driver-specific SELECT statement to retrieve an entity
+// by ID and lock its row until the end of the transaction, SQLite locks the whole database
+// for the writing transaction so the statement is the plain SELECT
+func createLockEntityStatement(driver, tableName string) (string, error) {
+	quotedTable := quoteIdentifier(driver, tableName)
+
+	switch driver {
+	case POSTGRES_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s WHERE id = $1 FOR UPDATE;`, quotedTable), nil
+	case SQLITE_DRIVER:
+		return createGetEntityStatement(driver, tableName)
+	default:
+		return "", getUnsupportedDriverError(driver)
+	}
+}
+
+// createFindByIdempotencyKeyStatement returns a driver-specific SELECT statement
+// to retrieve the evaluation job that was created with an idempotency key
+func createFindByIdempotencyKeyStatement(driver string) (string, error) {
+	quotedTable := quoteIdentifier(driver, TABLE_EVALUATIONS)
+
+	switch driver {
+	case POSTGRES_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s WHERE tenant_id = $1 AND idempotency_key = $2;`, quotedTable), nil
+	case SQLITE_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s WHERE tenant_id = ? AND idempotency_key = ?;`, quotedTable), nil
+	default:
+		return "", getUnsupportedDriverError(driver)
+	}
+}
+
+// createGetCollectionStatement returns a driver-specific SELECT statement
+// to retrieve a collection by ID, collections have no status or experiment columns
+func createGetCollectionStatement(driver string) (string, error) {
+	quotedTable := quoteIdentifier(driver, TABLE_COLLECTIONS)
+
+	switch driver {
+	case POSTGRES_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, entity FROM %s WHERE id = $1;`, quotedTable), nil
+	case SQLITE_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, entity FROM %s WHERE id = ?;`, quotedTable), nil
+	default:
+		return "", getUnsupportedDriverError(driver)
+	}
+}
+
+// createListCollectionsStatement returns a driver-specific SELECT statement
+// to list collections with pagination (LIMIT and OFFSET)
+func createListCollectionsStatement(driver string, limit, offset int) (string, []any, error) {
+	quotedTable := quoteIdentifier(driver, TABLE_COLLECTIONS)
+
+	switch driver {
+	case POSTGRES_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, entity FROM %s ORDER BY id DESC LIMIT $1 OFFSET $2;`, quotedTable), []any{limit, offset}, nil
+	case SQLITE_DRIVER:
+		return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, entity FROM %s ORDER BY id DESC LIMIT ? OFFSET ?;`, quotedTable), []any{limit, offset}, nil
+	default:
+		return "", nil, getUnsupportedDriverError(driver)
+	}
+}
+
+// createUpdateEntityStatement returns a driver-specific UPDATE statement
+// to replace the entity of a row by ID
+func createUpdateEntityStatement(driver, tableName string) (string, error) {
+	quotedTable := quoteIdentifier(driver, tableName)
+
+	switch driver {
+	case POSTGRES_DRIVER:
+		return fmt.Sprintf(`UPDATE %s SET entity = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;`, quotedTable), nil
+	case SQLITE_DRIVER:
+		return fmt.Sprintf(`UPDATE %s SET entity = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`, quotedTable), nil
+	default:
+		return "", getUnsupportedDriverError(driver)
+	}
+}
+
 // createDeleteEntityStatement returns a driver-specific DELETE statement
 // to delete an entity by ID
 func createDeleteEntityStatement(driver, tableName string) (string, error) {
@@ -90,79 +204,117 @@ func createDeleteEntityStatement(driver, tableName string) (string, error) {
 }
 
 // createCountEntitiesStatement returns a driver-specific COUNT statement
-// to count total entities in the table, optionally filtered by status
-func createCountEntitiesStatement(driver, tableName string, statusFilter string) (string, []any, error) {
-	quotedTable := quoteIdentifier(driver, tableName)
-
-	var query string
-	var args []any
-
+// to count total entities in the table
+func createCountEntitiesStatement(driver, tableName string) (string, []any, error) {
 	switch driver {
-	case POSTGRES_DRIVER:
-		if statusFilter != "" {
-			query = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE status = $1;`, quotedTable)
-			args = []any{statusFilter}
-		} else {
-			query = fmt.Sprintf(`SELECT COUNT(*) FROM %s;`, quotedTable)
-		}
-	case SQLITE_DRIVER:
-		if statusFilter != "" {
-			query = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE status = ?;`, quotedTable)
-			args = []any{statusFilter}
-		} else {
-			query = fmt.Sprintf(`SELECT COUNT(*) FROM %s;`, quotedTable)
-		}
+	case POSTGRES_DRIVER, SQLITE_DRIVER:
+		return fmt.Sprintf(`SELECT COUNT(*) FROM %s;`, quoteIdentifier(driver, tableName)), nil, nil
 	default:
 		return "", nil, getUnsupportedDriverError(driver)
 	}
+}
 
-	return query, args, nil
+// createCountEntitiesByStatusStatement returns a driver-specific SELECT statement
+// that counts the entities of the table grouped by status
+func createCountEntitiesByStatusStatement(driver, tableName string) (string, error) {
+	switch driver {
+	case POSTGRES_DRIVER, SQLITE_DRIVER:
+		return fmt.Sprintf(`SELECT status, COUNT(*) FROM %s GROUP BY status;`, quoteIdentifier(driver, tableName)), nil
+	default:
+		return "", getUnsupportedDriverError(driver)
+	}
 }
 
-// createListEntitiesStatement returns a driver-specific SELECT statement
-// to list entities with pagination (LIMIT and OFFSET), optionally filtered by status
-func createListEntitiesStatement(driver, tableName string, limit, offset int, statusFilter string) (string, []any, error) {
-	quotedTable := quoteIdentifier(driver, tableName)
+// placeholder returns the driver-specific placeholder of the n-th argument, counted from 1
+func placeholder(driver string, n int) string {
+	if driver == POSTGRES_DRIVER {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// timestampArg returns the argument to compare with a timestamp column. SQLite stores
+// timestamps as text, the format sorts like CURRENT_TIMESTAMP so that they compare as text.
+func timestampArg(driver string, t time.Time) any {
+	if driver == SQLITE_DRIVER {
+		return t.UTC().Format(SQLITE_TIMESTAMP_FORMAT)
+	}
+	return t.UTC()
+}
 
-	var query string
+// createEvaluationFilterClause returns the WHERE clause and its arguments for the conditions
+// of the filter that are set, the clause is empty when none is. Every condition is served by
+// an index of the evaluations table.
+func createEvaluationFilterClause(driver string, filter abstractions.EvaluationJobFilter) (string, []any) {
+	var conditions []string
 	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, condition+" "+placeholder(driver, len(args)))
+	}
+	if filter.Status != "" {
+		add("status =", filter.Status)
+	}
+	if filter.ModelName != "" {
+		add("model_name =", filter.ModelName)
+	}
+	if filter.CreatedAfter != nil {
+		add("created_at >", timestampArg(driver, *filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		add("created_at <", timestampArg(driver, *filter.CreatedBefore))
+	}
+	if filter.After != nil {
+		createdAt := timestampArg(driver, filter.After.CreatedAt)
+		args = append(args, createdAt, createdAt, filter.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at < %s OR (created_at = %s AND id < %s))",
+			placeholder(driver, len(args)-2), placeholder(driver, len(args)-1), placeholder(driver, len(args))))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
 
+// createCountEvaluationsStatement returns a driver-specific COUNT statement
+// to count the evaluation jobs that match the filter, whatever page is listed
+func createCountEvaluationsStatement(driver string, filter abstractions.EvaluationJobFilter) (string, []any, error) {
 	switch driver {
-	case POSTGRES_DRIVER:
-		if statusFilter != "" {
-			query = fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s WHERE status = $1 ORDER BY id DESC LIMIT $2 OFFSET $3;`, quotedTable)
-			args = []any{statusFilter, limit, offset}
-		} else {
-			query = fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s ORDER BY id DESC LIMIT $1 OFFSET $2;`, quotedTable)
-			args = []any{limit, offset}
-		}
-	case SQLITE_DRIVER:
-		if statusFilter != "" {
-			query = fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s WHERE status = ? ORDER BY id DESC LIMIT ? OFFSET ?;`, quotedTable)
-			args = []any{statusFilter, limit, offset}
-		} else {
-			query = fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s ORDER BY id DESC LIMIT ? OFFSET ?;`, quotedTable)
-			args = []any{limit, offset}
-		}
+	case POSTGRES_DRIVER, SQLITE_DRIVER:
+		filter.After = nil
+		where, args := createEvaluationFilterClause(driver, filter)
+		return fmt.Sprintf(`SELECT COUNT(*) FROM %s%s;`, quoteIdentifier(driver, TABLE_EVALUATIONS), where), args, nil
 	default:
 		return "", nil, getUnsupportedDriverError(driver)
 	}
+}
 
-	return query, args, nil
+// createListEvaluationsStatement returns a driver-specific SELECT statement to list the
+// evaluation jobs that match the filter with pagination (LIMIT and OFFSET), the newest first
+func createListEvaluationsStatement(driver string, limit, offset int, filter abstractions.EvaluationJobFilter) (string, []any, error) {
+	switch driver {
+	case POSTGRES_DRIVER, SQLITE_DRIVER:
+		where, args := createEvaluationFilterClause(driver, filter)
+		query := fmt.Sprintf(`SELECT id, created_at, updated_at, status, experiment_id, entity FROM %s%s ORDER BY created_at DESC, id DESC LIMIT %s OFFSET %s;`,
+			quoteIdentifier(driver, TABLE_EVALUATIONS), where, placeholder(driver, len(args)+1), placeholder(driver, len(args)+2))
+		return query, append(args, limit, offset), nil
+	default:
+		return "", nil, getUnsupportedDriverError(driver)
+	}
 }
 
 // createUpdateStatusStatement returns a driver-specific UPDATE statement
-// to update the status of an entity by ID
+// to update the status and the update time of an entity by ID
 func createUpdateStatusStatement(driver, tableName string) (string, error) {
 	quotedTable := quoteIdentifier(driver, tableName)
 
 	switch driver {
 	case POSTGRES_DRIVER:
-		// PostgreSQL: use $1, $2 placeholders
-		return fmt.Sprintf(`UPDATE %s SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;`, quotedTable), nil
+		// PostgreSQL: use $1, $2, $3 placeholders
+		return fmt.Sprintf(`UPDATE %s SET status = $1, updated_at = $2 WHERE id = $3;`, quotedTable), nil
 	case SQLITE_DRIVER:
 		// SQLite: use ? placeholders
-		return fmt.Sprintf(`UPDATE %s SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`, quotedTable), nil
+		return fmt.Sprintf(`UPDATE %s SET status = ?, updated_at = ? WHERE id = ?;`, quotedTable), nil
 	default:
 		return "", getUnsupportedDriverError(driver)
 	}
@@ -171,8 +323,9 @@ func createUpdateStatusStatement(driver, tableName string) (string, error) {
 // CreateUpdateEvaluationStatement returns a driver-specific UPDATE statement for the evaluations table,
 // setting only the non-empty fields (status, entity) and updated_at, filtered by id.
 // If status is empty, the query does not set status; if entityJSON is empty, the query does not set entity.
+// updatedAt is the timestamp argument of updated_at, it is always set.
 // Returns the query, args in SET order then id, and an optional error.
-func CreateUpdateEvaluationStatement(driver, tableName, id string, status api.OverallState, entityJSON string) (query string, args []any, err error) {
+func CreateUpdateEvaluationStatement(driver, tableName, id string, status api.OverallState, entityJSON string, updatedAt any) (query string, args []any, err error) {
 	quotedTable := quoteIdentifier(driver, tableName)
 	quotedStatus := quoteIdentifier(driver, "status")
 	quotedEntity := quoteIdentifier(driver, "entity")
@@ -189,8 +342,8 @@ func CreateUpdateEvaluationStatement(driver, tableName, id string, status api.Ov
 		setParts = append(setParts, quotedEntity)
 		argsList = append(argsList, entityJSON)
 	}
-	setParts = append(setParts, fmt.Sprintf("%s = CURRENT_TIMESTAMP", quotedUpdatedAt))
-	argsList = append(argsList, id)
+	setParts = append(setParts, quotedUpdatedAt)
+	argsList = append(argsList, updatedAt, id)
 
 	switch driver {
 	case POSTGRES_DRIVER:
@@ -204,12 +357,8 @@ func CreateUpdateEvaluationStatement(driver, tableName, id string, status api.Ov
 
 func createUpdateEvaluationStatementForSQLite(setParts []string, query string, quotedTable string, quotedID string, args []any, argsList []any) (string, []any, error) {
 	placeholders := make([]string, 0, len(setParts))
-	for i, part := range setParts {
-		if i < len(setParts)-1 {
-			placeholders = append(placeholders, part+" = ?")
-		} else {
-			placeholders = append(placeholders, part)
-		}
+	for _, part := range setParts {
+		placeholders = append(placeholders, part+" = ?")
 	}
 	query = fmt.Sprintf(`UPDATE %s SET %s WHERE %s = ?;`,
 		quotedTable, strings.Join(placeholders, ", "), quotedID)
@@ -220,11 +369,7 @@ func createUpdateEvaluationStatementForSQLite(setParts []string, query string, q
 func createUpdateEvaluationStatementForPostgres(setParts []string, argsList []any, query string, quotedTable string, quotedID string, args []any) (string, []any, error) {
 	placeholders := make([]string, 0, len(setParts))
 	for i := range setParts {
-		if i < len(setParts)-1 {
-			placeholders = append(placeholders, fmt.Sprintf("%s = $%d", setParts[i], i+1))
-		} else {
-			placeholders = append(placeholders, setParts[i])
-		}
+		placeholders = append(placeholders, fmt.Sprintf("%s = $%d", setParts[i], i+1))
 	}
 	whereIdx := len(argsList)
 	query = fmt.Sprintf(`UPDATE %s SET %s WHERE %s = $%d;`,
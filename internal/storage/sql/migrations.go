@@ -0,0 +1,126 @@
+package sql
+
+import (
+	"fmt"
+)
+
+// schemaMigration holds the statements of one schema version for every supported driver.
+type schemaMigration struct {
+	version     int
+	description string
+	statements  map[string]string
+}
+
+// schemaMigrations are applied in order of version and recorded in the schema_migrations
+// table. Append new migrations to the end and never change one that has been released, the
+// statements should be idempotent as databases created before the migrations were tracked
+// already have the tables of the first version.
+var schemaMigrations = []schemaMigration{
+	{
+		version:     1,
+		description: "create the evaluations and collections tables",
+		statements: map[string]string{
+			SQLITE_DRIVER:   SQLITE_SCHEMA_V1,
+			POSTGRES_DRIVER: POSTGRES_SCHEMA_V1,
+		},
+	},
+	{
+		version:     2,
+		description: "index the status of evaluations",
+		statements: map[string]string{
+			SQLITE_DRIVER:   EVALUATIONS_STATUS_INDEX_V2,
+			POSTGRES_DRIVER: EVALUATIONS_STATUS_INDEX_V2,
+		},
+	},
+	{
+		version:     3,
+		description: "add the idempotency key of evaluations",
+		statements: map[string]string{
+			SQLITE_DRIVER:   EVALUATIONS_IDEMPOTENCY_KEY_V3,
+			POSTGRES_DRIVER: EVALUATIONS_IDEMPOTENCY_KEY_V3,
+		},
+	},
+	{
+		version:     4,
+		description: "index the model name and creation time of evaluations",
+		statements: map[string]string{
+			SQLITE_DRIVER:   SQLITE_EVALUATIONS_MODEL_NAME_V4,
+			POSTGRES_DRIVER: POSTGRES_EVALUATIONS_MODEL_NAME_V4,
+		},
+	},
+	{
+		version:     5,
+		description: "order the index of the evaluation filters by creation time",
+		statements: map[string]string{
+			SQLITE_DRIVER:   EVALUATIONS_LIST_ORDER_V5,
+			POSTGRES_DRIVER: EVALUATIONS_LIST_ORDER_V5,
+		},
+	},
+}
+
+// latestSchemaVersion is the schema version this binary works with.
+func latestSchemaVersion() int {
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
+// migrate applies the migrations newer than the version recorded in the database, each one
+// in its own transaction. It refuses to run against a database migrated by a newer binary.
+func (s *SQLStorage) migrate() error {
+	if _, err := s.exec(nil, SCHEMA_MIGRATIONS_TABLE); err != nil {
+		return fmt.Errorf("failed to create the schema_migrations table: %w", err)
+	}
+
+	current, err := s.schemaVersion()
+	if err != nil {
+		return err
+	}
+	latest := latestSchemaVersion()
+	if current > latest {
+		return fmt.Errorf("database schema version %d is newer than version %d supported by this service", current, latest)
+	}
+
+	insertQuery, err := createInsertMigrationStatement(s.sqlConfig.Driver)
+	if err != nil {
+		return err
+	}
+	for _, migration := range schemaMigrations {
+		if migration.version <= current {
+			continue
+		}
+		statements, err := migrationForDriver(migration, s.sqlConfig.Driver)
+		if err != nil {
+			return err
+		}
+		if err := s.applyMigration(migration, statements, insertQuery); err != nil {
+			return fmt.Errorf("failed to apply schema migration %d (%s): %w", migration.version, migration.description, err)
+		}
+		s.logger.Info("Applied schema migration", "version", migration.version, "description", migration.description)
+	}
+	return nil
+}
+
+func (s *SQLStorage) applyMigration(migration schemaMigration, statements string, insertQuery string) error {
+	txn, err := s.pool.BeginTx(s.ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = txn.Rollback() }()
+
+	if _, err := s.exec(txn, statements); err != nil {
+		return err
+	}
+	if _, err := s.exec(txn, insertQuery, migration.version, migration.description); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// schemaVersion returns the highest applied migration version, 0 for a new database.
+func (s *SQLStorage) schemaVersion() (int, error) {
+	var version int
+	err := s.pool.QueryRowContext(s.ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the schema version: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,124 @@
+package sql_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/storage"
+)
+
+// openMigrationsDB keeps a connection open so that the in-memory database outlives the storage
+func openMigrationsDB(t *testing.T, url string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", url)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Fatalf("Failed to ping database: %v", err)
+	}
+	return db
+}
+
+func newMigratedStorage(url string) error {
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           url,
+		"database_name": "eval_hub",
+	}
+	_, err := storage.NewStorage(&databaseConfig, logging.FallbackLogger())
+	return err
+}
+
+func appliedVersions(t *testing.T, db *sql.DB) []int {
+	t.Helper()
+	rows, err := db.Query(`SELECT version FROM schema_migrations ORDER BY version;`)
+	if err != nil {
+		t.Fatalf("Failed to query schema_migrations: %v", err)
+	}
+	defer rows.Close()
+	var versions []int
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			t.Fatalf("Failed to scan version: %v", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions
+}
+
+func TestMigrationsRunForwardOnFreshDatabase(t *testing.T) {
+	url := "file:migrations_fresh?mode=memory&cache=shared"
+	db := openMigrationsDB(t, url)
+
+	if err := newMigratedStorage(url); err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	versions := appliedVersions(t, db)
+	if len(versions) < 2 {
+		t.Fatalf("Expected every migration to be applied, got %v", versions)
+	}
+	for i, version := range versions {
+		if version != i+1 {
+			t.Fatalf("Expected the migrations to be applied in order, got %v", versions)
+		}
+	}
+	for _, table := range []string{"evaluations", "collections"} {
+		var name string
+		err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?;`, table).Scan(&name)
+		if err != nil {
+			t.Fatalf("Expected table %s to be created: %v", table, err)
+		}
+	}
+}
+
+func TestMigrationsAreNoOpOnMigratedDatabase(t *testing.T) {
+	url := "file:migrations_noop?mode=memory&cache=shared"
+	db := openMigrationsDB(t, url)
+
+	if err := newMigratedStorage(url); err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	var appliedAt string
+	if err := db.QueryRow(`SELECT applied_at FROM schema_migrations WHERE version = 1;`).Scan(&appliedAt); err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+
+	versions := appliedVersions(t, db)
+
+	if err := newMigratedStorage(url); err != nil {
+		t.Fatalf("Failed to create storage on a migrated database: %v", err)
+	}
+	if reapplied := appliedVersions(t, db); len(reapplied) != len(versions) {
+		t.Fatalf("Expected the applied versions to be unchanged, got %v then %v", versions, reapplied)
+	}
+	var reappliedAt string
+	if err := db.QueryRow(`SELECT applied_at FROM schema_migrations WHERE version = 1;`).Scan(&reappliedAt); err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if reappliedAt != appliedAt {
+		t.Fatalf("Expected migration 1 not to be reapplied, applied at %s then %s", appliedAt, reappliedAt)
+	}
+}
+
+func TestMigrationsRefuseNewerDatabase(t *testing.T) {
+	url := "file:migrations_newer?mode=memory&cache=shared"
+	db := openMigrationsDB(t, url)
+
+	if err := newMigratedStorage(url); err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO schema_migrations (version, description) VALUES (1000, 'from the future');`); err != nil {
+		t.Fatalf("Failed to record a newer version: %v", err)
+	}
+
+	err := newMigratedStorage(url)
+	if err == nil || !strings.Contains(err.Error(), "newer than version") {
+		t.Fatalf("Expected a newer schema version error, got %v", err)
+	}
+}
@@ -2,7 +2,7 @@ package sql
 
 // SQLITE SCHEMAS
 
-const SQLITE_SCHEMA = `
+const SQLITE_SCHEMA_V1 = `
 CREATE TABLE IF NOT EXISTS evaluations (
     id VARCHAR(36) NOT NULL,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -32,7 +32,7 @@ ON collections (id);
 
 // POSTGRES SCHEMAS
 
-const POSTGRES_SCHEMA = `
+const POSTGRES_SCHEMA_V1 = `
 CREATE TABLE IF NOT EXISTS evaluations (
     id VARCHAR(36) NOT NULL,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -53,3 +53,69 @@ CREATE TABLE IF NOT EXISTS collections (
     PRIMARY KEY (id)
 );
 `
+
+// SHARED SCHEMAS
+
+const SCHEMA_MIGRATIONS_TABLE = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (version)
+);
+`
+
+// status is the source of truth for the job state (a cancel only updates the column), the
+// id serves the ORDER BY of the list queries. The collections need no such index, they are
+// only looked up and ordered by their primary key and have no status.
+const EVALUATIONS_STATUS_INDEX_V2 = `
+CREATE INDEX IF NOT EXISTS idx_evaluations_status
+ON evaluations (status, id);
+`
+
+// the key is NULL for jobs created without one, NULLs never collide in the unique index
+const EVALUATIONS_IDEMPOTENCY_KEY_V3 = `
+ALTER TABLE evaluations ADD COLUMN idempotency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_evaluations_idempotency_key
+ON evaluations (tenant_id, idempotency_key);
+`
+
+// the model name is generated from the entity so that the jobs of a model are found through
+// the index, created_at serves the creation time filters of the list queries
+const SQLITE_EVALUATIONS_MODEL_NAME_V4 = `
+ALTER TABLE evaluations ADD COLUMN model_name VARCHAR(255)
+GENERATED ALWAYS AS (json_extract(entity, '$.config.model.name')) VIRTUAL;
+
+CREATE INDEX IF NOT EXISTS idx_evaluations_model_name
+ON evaluations (model_name, id);
+
+CREATE INDEX IF NOT EXISTS idx_evaluations_created_at
+ON evaluations (created_at, id);
+`
+
+const POSTGRES_EVALUATIONS_MODEL_NAME_V4 = `
+ALTER TABLE evaluations ADD COLUMN IF NOT EXISTS model_name VARCHAR(255)
+GENERATED ALWAYS AS (entity->'config'->'model'->>'name') STORED;
+
+CREATE INDEX IF NOT EXISTS idx_evaluations_model_name
+ON evaluations (model_name, id);
+
+CREATE INDEX IF NOT EXISTS idx_evaluations_created_at
+ON evaluations (created_at, id);
+`
+
+// the list queries order the jobs by creation time, the indexes of the filters carry it so
+// that a filtered page is read in order from the index. They replace the indexes of version
+// 2 and 4 that ordered by id.
+const EVALUATIONS_LIST_ORDER_V5 = `
+CREATE INDEX IF NOT EXISTS idx_evaluations_status_created_at
+ON evaluations (status, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_evaluations_model_name_created_at
+ON evaluations (model_name, created_at, id);
+
+DROP INDEX IF EXISTS idx_evaluations_status;
+
+DROP INDEX IF EXISTS idx_evaluations_model_name;
+`
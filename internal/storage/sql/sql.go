@@ -22,14 +22,28 @@ const (
 	TABLE_COLLECTIONS = "collections"
 )
 
+// Clock tells the time that the evaluation jobs are stamped with, tests replace it so that
+// the timestamps are deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
 type SQLStorage struct {
 	sqlConfig *SQLDatabaseConfig
 	pool      *sql.DB
 	logger    *slog.Logger
 	ctx       context.Context
+	clock     Clock
+	observer  abstractions.TransitionObserver
 }
 
-func NewStorage(config map[string]any, logger *slog.Logger) (abstractions.Storage, error) {
+// NewStorage creates the SQL storage and migrates its schemas, a nil clock is replaced by the
+// system clock.
+func NewStorage(config map[string]any, logger *slog.Logger, clock Clock) (abstractions.Storage, error) {
 	var sqlConfig SQLDatabaseConfig
 	err := mapstructure.Decode(config, &sqlConfig)
 	if err != nil {
@@ -63,11 +77,15 @@ func NewStorage(config map[string]any, logger *slog.Logger) (abstractions.Storag
 		pool.SetMaxOpenConns(*sqlConfig.MaxOpenConns)
 	}
 
+	if clock == nil {
+		clock = systemClock{}
+	}
 	s := &SQLStorage{
 		sqlConfig: &sqlConfig,
 		pool:      pool,
 		logger:    logger,
 		ctx:       context.Background(),
+		clock:     clock,
 	}
 
 	// ping the database to verify the DSN provided by the user is valid and the server is accessible
@@ -77,22 +95,24 @@ func NewStorage(config map[string]any, logger *slog.Logger) (abstractions.Storag
 		return nil, err
 	}
 
-	// ensure the schemas are created
-	logger.Info("Ensuring schemas are created", "driver", sqlConfig.Driver, "url", sqlConfig.URL)
-	if err := s.ensureSchema(); err != nil {
+	// bring the schemas up to the version of this binary
+	logger.Info("Migrating schemas", "driver", sqlConfig.Driver, "url", sqlConfig.URL)
+	if err := s.migrate(); err != nil {
 		return nil, err
 	}
 
 	return s, nil
 }
 
-// Ping the database to verify DSN provided by the user is valid and the
-// server accessible. If the ping fails exit the program with an error.
+// Ping the database with a trivial query to verify DSN provided by the user is valid and the
+// server accessible. If the ping fails exit the program with an error. The timeout bounds
+// the ping within the context of the storage.
 func (s *SQLStorage) Ping(timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
 	defer cancel()
 
-	return s.pool.PingContext(ctx)
+	var one int
+	return s.pool.QueryRowContext(ctx, `SELECT 1;`).Scan(&one)
 }
 
 func (s *SQLStorage) GetDatasourceName() string {
@@ -107,18 +127,6 @@ func (s *SQLStorage) exec(txn *sql.Tx, query string, args ...any) (sql.Result, e
 	}
 }
 
-func (s *SQLStorage) ensureSchema() error {
-	schemas, err := schemasForDriver(s.sqlConfig.Driver)
-	if err != nil {
-		return err
-	}
-	if _, err := s.exec(nil, schemas); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (s *SQLStorage) getTenant() (api.Tenant, error) {
 	return "TODO", nil
 }
@@ -137,6 +145,8 @@ func (s *SQLStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
 		pool:      s.pool,
 		logger:    logger,
 		ctx:       s.ctx,
+		clock:     s.clock,
+		observer:  s.observer,
 	}
 }
 
@@ -146,5 +156,25 @@ func (s *SQLStorage) WithContext(ctx context.Context) abstractions.Storage {
 		pool:      s.pool,
 		logger:    s.logger,
 		ctx:       ctx,
+		clock:     s.clock,
+		observer:  s.observer,
+	}
+}
+
+func (s *SQLStorage) WithTransitionObserver(observer abstractions.TransitionObserver) abstractions.Storage {
+	return &SQLStorage{
+		sqlConfig: s.sqlConfig,
+		pool:      s.pool,
+		logger:    s.logger,
+		ctx:       s.ctx,
+		clock:     s.clock,
+		observer:  observer,
+	}
+}
+
+// observe reports the change of the overall state of the job that a committed update made.
+func (s *SQLStorage) observe(previous api.OverallState, job *api.EvaluationJobResource) {
+	if s.observer != nil && job.Status != nil && job.Status.State != previous {
+		s.observer(previous, job)
 	}
 }
@@ -15,5 +15,5 @@ func NewStorage(databaseConfig *map[string]any, logger *slog.Logger) (abstractio
 	if databaseConfig == nil {
 		return nil, serviceerrors.NewServiceError(messages.ConfigurationFailed, "Error", "database configuration")
 	}
-	return sql.NewStorage(*databaseConfig, logger)
+	return sql.NewStorage(*databaseConfig, logger, nil)
 }
@@ -80,7 +80,7 @@ func TestStorage(t *testing.T) {
 				},
 			},
 		}
-		resp, err := store.CreateEvaluationJob(job, "")
+		resp, err := store.CreateEvaluationJob(job, "", "")
 		if err != nil {
 			t.Fatalf("Failed to create evaluation job: %v", err)
 		}
@@ -101,7 +101,7 @@ func TestStorage(t *testing.T) {
 	})
 
 	t.Run("GetEvaluationJobs returns the evaluation jobs", func(t *testing.T) {
-		resp, err := store.GetEvaluationJobs(10, 0, "")
+		resp, err := store.GetEvaluationJobs(10, 0, abstractions.EvaluationJobFilter{})
 		if err != nil {
 			t.Fatalf("Failed to get evaluation jobs: %v", err)
 		}
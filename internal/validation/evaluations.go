@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/messages"
+	"github.com/eval-hub/eval-hub/internal/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// FieldError is a problem with a single field of a request, the field is the JSON path
+// of the value such as benchmarks[0].provider_id.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) String() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidateEvaluationJobConfig checks the parts of an evaluation job configuration that the
+// struct tags can not, such as the benchmarks referencing providers of the service. Every
+// problem that is found is reported in the returned error, nil means the configuration is valid.
+func ValidateEvaluationJobConfig(config *api.EvaluationJobConfig, providers map[string]api.ProviderResource) error {
+	fieldErrors := []FieldError{}
+
+	if config.Model.Name == "" {
+		fieldErrors = append(fieldErrors, FieldError{Field: "model.name", Message: "the model name is required"})
+	}
+	if config.Model.URL == "" {
+		fieldErrors = append(fieldErrors, FieldError{Field: "model.url", Message: "the model URL is required"})
+	} else if !isURL(config.Model.URL) {
+		fieldErrors = append(fieldErrors, FieldError{Field: "model.url", Message: fmt.Sprintf("'%s' is not a valid URL", config.Model.URL)})
+	}
+
+	if len(config.Benchmarks) == 0 {
+		fieldErrors = append(fieldErrors, FieldError{Field: "benchmarks", Message: "at least one benchmark is required"})
+	}
+	for i, benchmark := range config.Benchmarks {
+		provider, ok := providers[benchmark.ProviderID]
+		if !ok {
+			field := fmt.Sprintf("benchmarks[%d].provider_id", i)
+			fieldErrors = append(fieldErrors, FieldError{Field: field, Message: fmt.Sprintf("the provider '%s' does not exist", benchmark.ProviderID)})
+			continue
+		}
+		fieldErrors = append(fieldErrors, validateParameters(fmt.Sprintf("benchmarks[%d].parameters", i), &benchmark, &provider)...)
+	}
+
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	problems := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		problems = append(problems, fieldError.String())
+	}
+	return serviceerrors.NewServiceError(messages.EvaluationJobConfigInvalid, "Errors", strings.Join(problems, "; "))
+}
+
+// ValidateBenchmarkParameters checks the parameters of a benchmark against the JSON Schema
+// that its provider declares for them, the field errors are relative to the parameters such
+// as temperature. A nil schema accepts any parameters, an error means the schema is invalid.
+func ValidateBenchmarkParameters(parameters map[string]any, schema map[string]any) ([]FieldError, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	if parameters == nil {
+		parameters = map[string]any{}
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewGoLoader(parameters))
+	if err != nil {
+		return nil, err
+	}
+	fieldErrors := []FieldError{}
+	for _, resultError := range result.Errors() {
+		fieldErrors = append(fieldErrors, FieldError{Field: resultError.Field(), Message: resultError.Description()})
+	}
+	return fieldErrors, nil
+}
+
+// validateParameters returns the field errors of the parameters of a benchmark, prefixed
+// with the path of the parameters in the job configuration.
+func validateParameters(field string, benchmark *api.BenchmarkConfig, provider *api.ProviderResource) []FieldError {
+	var schema map[string]any
+	for _, resource := range provider.Benchmarks {
+		if resource.BenchmarkId == benchmark.ID {
+			schema = resource.ParametersSchema
+			break
+		}
+	}
+	parameterErrors, err := ValidateBenchmarkParameters(benchmark.Parameters, schema)
+	if err != nil {
+		return []FieldError{{Field: field, Message: fmt.Sprintf("the parameters schema of benchmark '%s' is invalid: %s", benchmark.ID, err.Error())}}
+	}
+	fieldErrors := make([]FieldError, 0, len(parameterErrors))
+	for _, parameterError := range parameterErrors {
+		// the errors of the parameters object itself, such as an unknown key, are on the root
+		if parameterError.Field == gojsonschema.STRING_CONTEXT_ROOT {
+			parameterError.Field = field
+		} else {
+			parameterError.Field = field + "." + parameterError.Field
+		}
+		fieldErrors = append(fieldErrors, parameterError)
+	}
+	return fieldErrors
+}
+
+// isURL returns true for an absolute URL with a host such as http://model:8000/v1
+func isURL(value string) bool {
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme != "" && parsed.Host != ""
+}
@@ -0,0 +1,156 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/abstractions"
+	"github.com/eval-hub/eval-hub/internal/constants"
+	"github.com/eval-hub/eval-hub/internal/validation"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+var providers = map[string]api.ProviderResource{"garak": {ProviderID: "garak"}}
+
+func TestValidateEvaluationJobConfigAcceptsValidConfig(t *testing.T) {
+	config := &api.EvaluationJobConfig{
+		Model:      api.ModelRef{URL: "http://model:8000/v1", Name: "model"},
+		Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "toxicity"}, ProviderID: "garak"}},
+	}
+	if err := validation.ValidateEvaluationJobConfig(config, providers); err != nil {
+		t.Fatalf("expected the config to be valid, got %v", err)
+	}
+}
+
+func TestValidateEvaluationJobConfigReportsEveryProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *api.EvaluationJobConfig
+		problems []string
+	}{
+		{
+			name:     "empty config",
+			config:   &api.EvaluationJobConfig{},
+			problems: []string{"model.name:", "model.url:", "benchmarks:"},
+		},
+		{
+			name: "invalid model url",
+			config: &api.EvaluationJobConfig{
+				Model:      api.ModelRef{URL: "model:8000", Name: "model"},
+				Benchmarks: []api.BenchmarkConfig{{Ref: api.Ref{ID: "toxicity"}, ProviderID: "garak"}},
+			},
+			problems: []string{"model.url: 'model:8000' is not a valid URL"},
+		},
+		{
+			name: "unknown providers",
+			config: &api.EvaluationJobConfig{
+				Model: api.ModelRef{URL: "http://model:8000", Name: "model"},
+				Benchmarks: []api.BenchmarkConfig{
+					{Ref: api.Ref{ID: "arc"}, ProviderID: "lighteval"},
+					{Ref: api.Ref{ID: "toxicity"}, ProviderID: "garak"},
+					{Ref: api.Ref{ID: "mmlu"}, ProviderID: ""},
+				},
+			},
+			problems: []string{"benchmarks[0].provider_id: the provider 'lighteval'", "benchmarks[2].provider_id: the provider ''"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.ValidateEvaluationJobConfig(tt.config, providers)
+			var serviceErr abstractions.ServiceError
+			if !errors.As(err, &serviceErr) {
+				t.Fatalf("expected a service error, got %v", err)
+			}
+			if serviceErr.MessageCode().GetCode() != constants.HTTPCodeUnprocessableEntity {
+				t.Fatalf("expected code 422, got %d", serviceErr.MessageCode().GetCode())
+			}
+			for _, problem := range tt.problems {
+				if !strings.Contains(err.Error(), problem) {
+					t.Fatalf("expected %q to be reported, got %v", problem, err)
+				}
+			}
+			if count := strings.Count(err.Error(), ";") + 1; count != len(tt.problems) {
+				t.Fatalf("expected %d problems, got %d: %v", len(tt.problems), count, err)
+			}
+		})
+	}
+}
+
+var generationSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"temperature": map[string]any{"type": "number", "minimum": 0, "maximum": 2},
+		"max_tokens":  map[string]any{"type": "integer", "minimum": 1},
+		"split":       map[string]any{"enum": []any{"test", "validation"}},
+	},
+	"required":             []any{"split"},
+	"additionalProperties": false,
+}
+
+func TestValidateBenchmarkParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]any
+		schema     map[string]any
+		problems   []string
+	}{
+		{name: "no schema accepts anything", parameters: map[string]any{"temprature": "hot"}},
+		{name: "valid parameters", parameters: map[string]any{"temperature": 0.7, "max_tokens": float64(256), "split": "test"}, schema: generationSchema},
+		{name: "unknown key", parameters: map[string]any{"temprature": 0.7, "split": "test"}, schema: generationSchema, problems: []string{"(root): Additional property temprature is not allowed"}},
+		{name: "wrong type", parameters: map[string]any{"temperature": "0.7", "split": "test"}, schema: generationSchema, problems: []string{"temperature: Invalid type"}},
+		{name: "not an integer", parameters: map[string]any{"max_tokens": 1.5, "split": "test"}, schema: generationSchema, problems: []string{"max_tokens: Invalid type"}},
+		{name: "out of range", parameters: map[string]any{"temperature": 3, "split": "test"}, schema: generationSchema, problems: []string{"temperature: Must be less than or equal to 2"}},
+		{name: "missing parameters", schema: generationSchema, problems: []string{"(root): split is required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErrors, err := validation.ValidateBenchmarkParameters(tt.parameters, tt.schema)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if len(fieldErrors) != len(tt.problems) {
+				t.Fatalf("expected %d problems, got %v", len(tt.problems), fieldErrors)
+			}
+			for i, problem := range tt.problems {
+				if !strings.HasPrefix(fieldErrors[i].String(), problem) {
+					t.Fatalf("expected %q to be reported, got %v", problem, fieldErrors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBenchmarkParametersRejectsInvalidSchema(t *testing.T) {
+	_, err := validation.ValidateBenchmarkParameters(map[string]any{}, map[string]any{"type": "objekt"})
+	if err == nil {
+		t.Fatalf("expected the schema to be rejected")
+	}
+}
+
+func TestValidateEvaluationJobConfigChecksParametersOfEachBenchmark(t *testing.T) {
+	providers := map[string]api.ProviderResource{
+		"lm_evaluation_harness": {
+			ProviderID: "lm_evaluation_harness",
+			Benchmarks: []api.BenchmarkResource{
+				{BenchmarkId: "arc_easy", ParametersSchema: generationSchema},
+				{BenchmarkId: "mmlu"},
+			},
+		},
+	}
+	config := &api.EvaluationJobConfig{
+		Model: api.ModelRef{URL: "http://model:8000", Name: "model"},
+		Benchmarks: []api.BenchmarkConfig{
+			{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"anything": true}},
+			{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"temperature": "hot", "split": "test"}},
+		},
+	}
+	err := validation.ValidateEvaluationJobConfig(config, providers)
+	var serviceErr abstractions.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.MessageCode().GetCode() != constants.HTTPCodeUnprocessableEntity {
+		t.Fatalf("expected a 422 error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "benchmarks[1].parameters.temperature: Invalid type") || strings.Contains(err.Error(), "benchmarks[0]") {
+		t.Fatalf("expected only the parameters of arc_easy to be reported, got %v", err)
+	}
+}
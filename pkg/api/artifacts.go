@@ -0,0 +1,51 @@
+package api
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// ArtifactSink is an S3 compatible bucket the benchmark Jobs upload their output directory to.
+//
+// Example YAML for the service config:
+//
+//	artifacts:
+//	  bucket: "eval-artifacts"
+//	  prefix: "eval-hub"
+//	  endpoint: "https://s3.example.com"
+//	  region: "us-east-1"
+//	  credentials_secret: "eval-artifacts-credentials"
+//	  upload_timeout: "5m"
+type ArtifactSink struct {
+	Bucket   string `mapstructure:"bucket" yaml:"bucket"`
+	Prefix   string `mapstructure:"prefix" yaml:"prefix"`
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint"`
+	Region   string `mapstructure:"region" yaml:"region"`
+	// CredentialsSecret is a Secret in the namespace of the Jobs whose keys, such as
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, become environment variables of the uploader.
+	CredentialsSecret string `mapstructure:"credentials_secret" yaml:"credentials_secret"`
+	// UploaderImage runs the upload, it needs a shell and the aws CLI.
+	UploaderImage string `mapstructure:"uploader_image" yaml:"uploader_image"`
+	// UploadTimeout is how long the upload may take once the benchmark finished.
+	UploadTimeout time.Duration `mapstructure:"upload_timeout" yaml:"upload_timeout"`
+}
+
+// BenchmarkURI returns the URI the output directory of the benchmark is uploaded to,
+// s3://<bucket>/<prefix>/<job id>/<benchmark id>/.
+func (s *ArtifactSink) BenchmarkURI(jobID string, benchmarkID string) string {
+	key := path.Join(strings.Trim(s.Prefix, "/"), jobID, benchmarkID)
+	return "s3://" + s.Bucket + "/" + strings.TrimPrefix(key, "/") + "/"
+}
+
+// ArtifactReference locates the output files a benchmark uploaded to the artifact sink
+type ArtifactReference struct {
+	BenchmarkID string `json:"benchmark_id"`
+	ProviderID  string `json:"provider_id"`
+	URI         string `json:"uri"`
+}
+
+// ArtifactReferenceList represents the artifacts of an evaluation job
+type ArtifactReferenceList struct {
+	Items []ArtifactReference `json:"items"`
+}
@@ -11,6 +11,9 @@ type BenchmarkResource struct {
 	NumFewShot  int      `mapstructure:"num_few_shot" yaml:"num_few_shot" json:"num_few_shot"`
 	DatasetSize int      `mapstructure:"dataset_size" yaml:"dataset_size" json:"dataset_size"`
 	Tags        []string `mapstructure:"tags" yaml:"tags" json:"tags"`
+	// ParametersSchema is a JSON Schema that the parameters of the benchmark must match, any
+	// parameters are accepted when it is not set
+	ParametersSchema map[string]any `mapstructure:"parameters_schema" yaml:"parameters_schema" json:"parameters_schema,omitempty"`
 }
 
 // BenchmarkResourceList represents list of benchmarks
@@ -1,10 +1,24 @@
 package api
 
-// CollectionConfig represents request to create a collection
+// CollectionConfig represents the user specified part of a collection
 type CollectionConfig struct {
-	Name        string   `json:"name"`
-	Description *string  `json:"description,omitempty"`
-	Benchmarks  []string `json:"benchmarks"`
+	Name        string            `json:"name" validate:"required"`
+	Description *string           `json:"description,omitempty"`
+	Benchmarks  []BenchmarkConfig `json:"benchmarks" validate:"required,min=1,dive"`
+}
+
+// CollectionCreationRequest represents request to create a collection, the ID is generated when not set
+type CollectionCreationRequest struct {
+	ID string `json:"id,omitempty"`
+	CollectionConfig
+}
+
+// CollectionRunRequest represents request to run the benchmarks of a collection against a model
+type CollectionRunRequest struct {
+	Model          ModelRef          `json:"model"`
+	Experiment     *ExperimentConfig `json:"experiment,omitempty"`
+	TimeoutMinutes *int              `json:"timeout_minutes,omitempty"`
+	RetryAttempts  *int              `json:"retry_attempts,omitempty"`
 }
 
 // CollectionResource represents collection resource
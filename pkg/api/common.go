@@ -63,6 +63,9 @@ type Page struct {
 	Next       *HRef `json:"next,omitempty"`
 	Limit      int   `json:"limit"`
 	TotalCount int   `json:"total_count"`
+	// NextCursor continues the list after the last item of the page, for the lists that
+	// support cursors
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // EnvVar captures environment variables for the job template.
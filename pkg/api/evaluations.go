@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -52,8 +53,8 @@ func GetOverallState(s string) (OverallState, error) {
 
 // ModelRef represents model specification for evaluation requests
 type ModelRef struct {
-	URL  string `json:"url" validate:"required"`
-	Name string `json:"name" validate:"required"`
+	URL  string `json:"url"`
+	Name string `json:"name"`
 }
 
 // MessageInfo represents a message from a downstream service
@@ -89,10 +90,12 @@ type BenchmarkStatusLogs struct {
 
 // BenchmarkStatus represents status of individual benchmark in evaluation
 type BenchmarkStatus struct {
-	ProviderID      string       `json:"provider_id"`
-	ID              string       `json:"id"`
-	Status          State        `json:"status,omitempty"`
-	ErrorMessage    *MessageInfo `json:"error_message,omitempty"`
+	ProviderID   string       `json:"provider_id"`
+	ID           string       `json:"id"`
+	Status       State        `json:"status,omitempty"`
+	ErrorMessage *MessageInfo `json:"error_message,omitempty"`
+	// Message explains a benchmark that completed without metrics
+	Message         *MessageInfo `json:"message,omitempty"`
 	StartedAt       *time.Time   `json:"started_at,omitempty"`
 	CompletedAt     *time.Time   `json:"completed_at,omitempty"`
 	DurationSeconds int64        `json:"duration_seconds,omitempty"`
@@ -106,6 +109,7 @@ type BenchmarkStatusEvent struct {
 	Metrics         map[string]any `json:"metrics,omitempty"`
 	Artifacts       map[string]any `json:"artifacts,omitempty"`
 	ErrorMessage    *MessageInfo   `json:"error_message,omitempty"`
+	Message         *MessageInfo   `json:"message,omitempty"`
 	StartedAt       *time.Time     `json:"started_at,omitempty"`
 	CompletedAt     *time.Time     `json:"completed_at,omitempty"`
 	DurationSeconds int64          `json:"duration_seconds,omitempty"`
@@ -142,12 +146,30 @@ type EvaluationJobResults struct {
 
 // EvaluationJobConfig represents evaluation job request schema
 type EvaluationJobConfig struct {
-	Model          ModelRef          `json:"model" validate:"required"`
-	Benchmarks     []BenchmarkConfig `json:"benchmarks" validate:"required,min=1,dive"`
+	Model          ModelRef          `json:"model"`
+	Benchmarks     []BenchmarkConfig `json:"benchmarks" validate:"dive"`
 	Collection     Ref               `json:"collection"`
 	Experiment     *ExperimentConfig `json:"experiment,omitempty"`
 	TimeoutMinutes *int              `json:"timeout_minutes,omitempty"`
 	RetryAttempts  *int              `json:"retry_attempts,omitempty"`
+	// CallbackURL receives a POST of the SummaryResponse of the job once the job finished
+	CallbackURL string `json:"callback_url,omitempty" validate:"omitempty,http_url"`
+}
+
+// RenderedResource is a resource a runtime would create for a benchmark of an evaluation job,
+// the manifest is the object as it would be sent to the runtime.
+type RenderedResource struct {
+	Kind        string          `json:"kind"`
+	Name        string          `json:"name"`
+	ProviderID  string          `json:"provider_id"`
+	BenchmarkID string          `json:"benchmark_id"`
+	Manifest    json.RawMessage `json:"manifest"`
+}
+
+// EvaluationJobDryRun represents the response of a dry run of an evaluation job
+type EvaluationJobDryRun struct {
+	EvaluationJobConfig
+	Resources []RenderedResource `json:"resources"`
 }
 
 type EvaluationResource struct {
@@ -166,6 +188,9 @@ type EvaluationJobResource struct {
 	Resource EvaluationResource    `json:"resource"`
 	Status   *EvaluationJobStatus  `json:"status,omitempty"`
 	Results  *EvaluationJobResults `json:"results,omitempty"`
+	// Artifacts are recorded as the benchmarks are submitted, the files are there once the
+	// benchmark has finished.
+	Artifacts []ArtifactReference `json:"artifacts,omitempty"`
 	EvaluationJobConfig
 }
 
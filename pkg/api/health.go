@@ -11,3 +11,15 @@ type HealthResponse struct {
 	Uptime            time.Duration             `json:"uptime"`
 	ActiveEvaluations int                       `json:"active_evaluations,omitempty"`
 }
+
+// ReadinessResponse represents the readiness of the service and of each of its dependencies
+type ReadinessResponse struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// DependencyStatus represents the outcome of the readiness check of a dependency
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,46 @@
+package api
+
+import "time"
+
+// SystemMetrics represents the response of the system metrics endpoint. Every field is
+// always present so that the response can back a dashboard.
+//
+// Example:
+//
+//	{
+//	  "timestamp": "2026-01-01T12:00:00Z",
+//	  "evaluation_jobs": {"total": 3, "pending": 1, "running": 1, "completed": 1, "failed": 0, "cancelled": 0, "partially_failed": 0},
+//	  "runtime": {"name": "kubernetes", "active_jobs": 2},
+//	  "process": {"start_time": "2026-01-01T11:00:00Z", "uptime_seconds": 3600, "goroutines": 42, "heap_alloc_bytes": 10485760}
+//	}
+type SystemMetrics struct {
+	Timestamp      time.Time           `json:"timestamp"`
+	EvaluationJobs EvaluationJobCounts `json:"evaluation_jobs"`
+	Runtime        RuntimeMetrics      `json:"runtime"`
+	Process        ProcessMetrics      `json:"process"`
+}
+
+// EvaluationJobCounts represents the number of evaluation jobs in each status
+type EvaluationJobCounts struct {
+	Total           int `json:"total"`
+	Pending         int `json:"pending"`
+	Running         int `json:"running"`
+	Completed       int `json:"completed"`
+	Failed          int `json:"failed"`
+	Cancelled       int `json:"cancelled"`
+	PartiallyFailed int `json:"partially_failed"`
+}
+
+// RuntimeMetrics represents the workloads of the runtime, the name is empty when no runtime is configured
+type RuntimeMetrics struct {
+	Name       string `json:"name"`
+	ActiveJobs int    `json:"active_jobs"`
+}
+
+// ProcessMetrics represents the statistics of the service process
+type ProcessMetrics struct {
+	StartTime      time.Time `json:"start_time"`
+	UptimeSeconds  int64     `json:"uptime_seconds"`
+	Goroutines     int       `json:"goroutines"`
+	HeapAllocBytes uint64    `json:"heap_alloc_bytes"`
+}
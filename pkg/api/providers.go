@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // Provider contains the configuration details for an evaluation provider.
 type ProviderResource struct {
 	ProviderID   string              `mapstructure:"provider_id" yaml:"provider_id" json:"provider_id"`
@@ -11,8 +13,9 @@ type ProviderResource struct {
 }
 
 type Runtime struct {
-	K8s   *K8sRuntime   `mapstructure:"k8s" yaml:"k8s" json:"k8s,omitempty"`
-	Local *LocalRuntime `mapstructure:"local" yaml:"local" json:"local,omitempty"`
+	K8s    *K8sRuntime    `mapstructure:"k8s" yaml:"k8s" json:"k8s,omitempty"`
+	Docker *DockerRuntime `mapstructure:"docker" yaml:"docker" json:"docker,omitempty"`
+	Local  *LocalRuntime  `mapstructure:"local" yaml:"local" json:"local,omitempty"`
 }
 
 // ProviderRuntime contains runtime configuration for Kubernetes jobs.
@@ -21,6 +24,22 @@ type Runtime struct {
 //
 //	runtime:
 //	  image: "quay.io/eval-hub/adapter:latest"
+//	  namespace: "eval-jobs"
+//	  timeout: "2h"
+//	  backoff_limit: 0
+//	  max_concurrent_benchmarks: 4
+//	  image_pull_policy: "IfNotPresent"
+//	  image_pull_secrets:
+//	    - "registry-credentials"
+//	  priority_class_name: "batch-low"
+//	  ttl_seconds_after_finished: 600
+//	  restart_policy: "OnFailure"
+//	  init_container:
+//	    image: "quay.io/eval-hub/dataset-prefetch:latest"
+//	  labels:
+//	    team: "evaluation"
+//	  annotations:
+//	    example.com/owner: "evaluation-team"
 //	  entrypoint:
 //	    - "/path/to/program"
 //	  cpu_request: "250m"
@@ -38,6 +57,102 @@ type K8sRuntime struct {
 	CPULimit      string   `mapstructure:"cpu_limit" yaml:"cpu_limit"`
 	MemoryLimit   string   `mapstructure:"memory_limit" yaml:"memory_limit"`
 	Env           []EnvVar `mapstructure:"env" yaml:"env"`
+	// Namespace is the namespace where the Jobs and ConfigMaps are created. When empty the
+	// namespace of the service pod is used, falling back to "default" outside a cluster.
+	Namespace string `mapstructure:"namespace" yaml:"namespace"`
+	// Timeout becomes the ActiveDeadlineSeconds of each benchmark Job and BackoffLimit the
+	// number of retries of a failed benchmark pod (0 when unset). The timeout_minutes and
+	// retry_attempts of an evaluation job take precedence over these provider defaults.
+	Timeout      time.Duration `mapstructure:"timeout" yaml:"timeout"`
+	BackoffLimit *int32        `mapstructure:"backoff_limit" yaml:"backoff_limit"`
+	// TTLSecondsAfterFinished is how long a finished benchmark Job is kept before Kubernetes
+	// deletes it together with its pods and ConfigMap, 3600 when unset.
+	TTLSecondsAfterFinished *int32 `mapstructure:"ttl_seconds_after_finished" yaml:"ttl_seconds_after_finished"`
+	// MaxConcurrentBenchmarks caps the benchmark Jobs of the provider that run at the same
+	// time for one evaluation job, the others are created as the running ones finish. When
+	// 0 every benchmark Job is created at once.
+	MaxConcurrentBenchmarks int `mapstructure:"max_concurrent_benchmarks" yaml:"max_concurrent_benchmarks"`
+	// Labels and Annotations are added to the Jobs, pods and ConfigMaps of the provider next
+	// to the eval-hub labels, which they can not replace.
+	Labels      map[string]string `mapstructure:"labels" yaml:"labels"`
+	Annotations map[string]string `mapstructure:"annotations" yaml:"annotations"`
+	// ImagePullPolicy is one of Always, IfNotPresent or Never, Always when unset.
+	// ImagePullSecrets are the names of the secrets used to pull the adapter image.
+	ImagePullPolicy  string   `mapstructure:"image_pull_policy" yaml:"image_pull_policy"`
+	ImagePullSecrets []string `mapstructure:"image_pull_secrets" yaml:"image_pull_secrets"`
+	// PriorityClassName is the priority class of the benchmark pods, the cluster default when unset.
+	// RestartPolicy of the benchmark pods is Never or OnFailure, Never when unset.
+	PriorityClassName string `mapstructure:"priority_class_name" yaml:"priority_class_name"`
+	RestartPolicy     string `mapstructure:"restart_policy" yaml:"restart_policy"`
+	// InitContainer runs before the adapter of every benchmark, for example to download
+	// the datasets of the benchmark.
+	InitContainer *K8sInitContainer `mapstructure:"init_container" yaml:"init_container"`
+	// ScratchVolume is an emptyDir mounted in the adapter container for its intermediate
+	// files, so that they do not fill the ephemeral storage of the node.
+	ScratchVolume *K8sScratchVolume `mapstructure:"scratch_volume" yaml:"scratch_volume"`
+	// ConfigMountPath is the directory the job spec of a benchmark is mounted in, /meta when
+	// unset. ConfigFileName is the name of the file, and its key in the ConfigMap, job.json
+	// when unset.
+	ConfigMountPath string `mapstructure:"config_mount_path" yaml:"config_mount_path"`
+	ConfigFileName  string `mapstructure:"config_file_name" yaml:"config_file_name"`
+}
+
+// K8sScratchVolume is an emptyDir volume of the adapter container whose size is limited,
+// the pod is evicted when the adapter writes more than SizeLimit to it.
+//
+// Example YAML for provider configs:
+//
+//	scratch_volume:
+//	  mount_path: "/scratch"
+//	  size_limit: "10Gi"
+type K8sScratchVolume struct {
+	MountPath string `mapstructure:"mount_path" yaml:"mount_path"`
+	// SizeLimit is a Kubernetes quantity such as 500Mi or 10Gi
+	SizeLimit string `mapstructure:"size_limit" yaml:"size_limit"`
+}
+
+// K8sInitContainer is a container that runs to completion before the adapter container. It
+// shares a volume mounted at MountPath with the adapter, whose EVALHUB_PREFETCH_DIR points
+// to the volume so that it can read what the init container downloaded.
+//
+// Example YAML for provider configs:
+//
+//	init_container:
+//	  image: "quay.io/eval-hub/dataset-prefetch:latest"
+//	  command:
+//	    - "/bin/prefetch"
+//	  env:
+//	    - name: DATASETS
+//	      value: "mmlu,arc"
+//	  mount_path: "/prefetch"
+type K8sInitContainer struct {
+	Image   string   `mapstructure:"image" yaml:"image"`
+	Command []string `mapstructure:"command" yaml:"command"`
+	Env     []EnvVar `mapstructure:"env" yaml:"env"`
+	// MountPath is where the shared volume is mounted in both containers, /prefetch when unset
+	MountPath string `mapstructure:"mount_path" yaml:"mount_path"`
+}
+
+// DockerRuntime contains runtime configuration for running benchmarks as local Docker containers.
+//
+// Example YAML for provider configs:
+//
+//	runtime:
+//	  docker:
+//	    image: "quay.io/eval-hub/adapter:latest"
+//	    entrypoint:
+//	      - "/path/to/program"
+//	    cpu_limit: "1"
+//	    memory_limit: "2Gi"
+//	    env:
+//	      - name: FOO
+//	        value: "bar"
+type DockerRuntime struct {
+	Image       string   `mapstructure:"image" yaml:"image"`
+	Entrypoint  []string `mapstructure:"entrypoint" yaml:"entrypoint"`
+	CPULimit    string   `mapstructure:"cpu_limit" yaml:"cpu_limit"`
+	MemoryLimit string   `mapstructure:"memory_limit" yaml:"memory_limit"`
+	Env         []EnvVar `mapstructure:"env" yaml:"env"`
 }
 
 type LocalRuntime struct {
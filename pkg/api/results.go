@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BenchmarkResultsFile is the name of the file an adapter writes the scores of its
+// benchmark to once the benchmark finished.
+const BenchmarkResultsFile = "results.json"
+
+// MaxBenchmarkResultsSize is the largest results file the Kubernetes runtime can read. The
+// file is the termination message of the adapter container, which the kubelet truncates to
+// 4KiB, so a larger file can not be parsed and its benchmark is completed without metrics.
+const MaxBenchmarkResultsSize = 4096
+
+// BenchmarkResults is the schema of the results file, the metrics map the name of each
+// metric to its score.
+//
+// Example:
+//
+//	{"metrics": {"accuracy": 0.82, "f1": 0.79}}
+type BenchmarkResults struct {
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// ParseBenchmarkResults parses the content of a results file, an error is returned when it
+// is not valid or has no metrics.
+func ParseBenchmarkResults(data []byte) (*BenchmarkResults, error) {
+	results := &BenchmarkResults{}
+	if err := json.Unmarshal(data, results); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", BenchmarkResultsFile, err)
+	}
+	if len(results.Metrics) == 0 {
+		return nil, fmt.Errorf("invalid %s: no metrics", BenchmarkResultsFile)
+	}
+	return results, nil
+}
+
+// MetricsMap returns the metrics in the form stored in the benchmark results.
+func (r *BenchmarkResults) MetricsMap() map[string]any {
+	metrics := make(map[string]any, len(r.Metrics))
+	for name, score := range r.Metrics {
+		metrics[name] = score
+	}
+	return metrics
+}
@@ -0,0 +1,66 @@
+package api
+
+import "time"
+
+// BenchmarkSummary is the outcome of a benchmark in the summary of an evaluation job.
+type BenchmarkSummary struct {
+	ID           string         `json:"id"`
+	ProviderID   string         `json:"provider_id"`
+	Status       State          `json:"status"`
+	ErrorMessage *MessageInfo   `json:"error_message,omitempty"`
+	Metrics      map[string]any `json:"metrics,omitempty"`
+}
+
+// SummaryResponse is the state of an evaluation job and the outcome of each of its
+// benchmarks, without the configuration of the job. It is returned by the summary endpoint
+// and posted to the callback URL of the job once the job finished.
+type SummaryResponse struct {
+	ID         string             `json:"id"`
+	State      OverallState       `json:"state"`
+	Message    *MessageInfo       `json:"message,omitempty"`
+	ModelName  string             `json:"model_name"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	Benchmarks []BenchmarkSummary `json:"benchmarks"`
+}
+
+// NewSummaryResponse summarizes the job. The benchmarks are in the order of the job, a
+// benchmark without a status yet is pending.
+func NewSummaryResponse(job *EvaluationJobResource) *SummaryResponse {
+	summary := &SummaryResponse{
+		ID:         job.Resource.ID,
+		State:      OverallStatePending,
+		ModelName:  job.Model.Name,
+		CreatedAt:  job.Resource.CreatedAt,
+		UpdatedAt:  job.Resource.UpdatedAt,
+		Benchmarks: make([]BenchmarkSummary, 0, len(job.Benchmarks)),
+	}
+	statuses := map[string]*BenchmarkStatus{}
+	if job.Status != nil {
+		summary.State = job.Status.State
+		summary.Message = job.Status.Message
+		for i := range job.Status.Benchmarks {
+			statuses[job.Status.Benchmarks[i].ID] = &job.Status.Benchmarks[i]
+		}
+	}
+	metrics := map[string]map[string]any{}
+	if job.Results != nil {
+		for _, result := range job.Results.Benchmarks {
+			metrics[result.ID] = result.Metrics
+		}
+	}
+	for _, benchmark := range job.Benchmarks {
+		benchmarkSummary := BenchmarkSummary{
+			ID:         benchmark.ID,
+			ProviderID: benchmark.ProviderID,
+			Status:     StatePending,
+			Metrics:    metrics[benchmark.ID],
+		}
+		if status, ok := statuses[benchmark.ID]; ok {
+			benchmarkSummary.Status = status.Status
+			benchmarkSummary.ErrorMessage = status.ErrorMessage
+		}
+		summary.Benchmarks = append(summary.Benchmarks, benchmarkSummary)
+	}
+	return summary
+}
@@ -0,0 +1,97 @@
+Feature: Collections Endpoint
+  As a data scientist
+  I want to manage collections of benchmarks
+  So that I can reuse them across evaluations
+
+  Scenario: Create, update and delete a collection
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection.json"
+    Then the response code should be 201
+    When I send a GET request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 200
+    And the response should contain the value "Safety collection" at path "$.name"
+    When I send a PUT request to "/api/v1/evaluations/collections/{id}" with body "file:/collection_update.json"
+    Then the response code should be 200
+    When I send a GET request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 200
+    And the response should contain the value "Updated safety collection" at path "$.name"
+    When I send a DELETE request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 204
+    When I send a GET request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 404
+    When I send a DELETE request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 404
+
+  Scenario: Create a collection with a duplicate id
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection_with_id.json"
+    Then the response code should be 201
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection_with_id.json"
+    Then the response code should be 409
+
+  Scenario: Create an invalid collection
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection_invalid.json"
+    Then the response code should be 400
+
+  Scenario: List collections
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection.json"
+    Then the response code should be 201
+    When I send a GET request to "/api/v1/evaluations/collections"
+    Then the response code should be 200
+    And the response should have schema as:
+    """
+      {
+        "properties": {
+            "limit": {"type": "integer"},
+            "total_count": {"type": "integer", "minimum": 1},
+            "items": {"type": "array", "minItems": 1}
+        },
+        "required": ["limit", "total_count", "items"]
+      }
+    """
+
+  Scenario: Patch a collection
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection.json"
+    Then the response code should be 201
+    When I send a PATCH request to "/api/v1/evaluations/collections/{id}" with body "file:/collection_patch.json"
+    Then the response code should be 200
+    When I send a GET request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 200
+    And the response should contain the value "Patched safety collection" at path "$.name"
+    And the response should contain the value "toxicity" at path "$.benchmarks[0].id"
+    And the response should have schema as:
+    """
+      {
+        "not": {"required": ["description"]}
+      }
+    """
+    When I send a PATCH request to "/api/v1/evaluations/collections/{id}" with body "file:/collection_patch_invalid.json"
+    Then the response code should be 400
+    When I send a GET request to "/api/v1/evaluations/collections/{id}"
+    Then the response code should be 200
+    And the response should contain the value "Patched safety collection" at path "$.name"
+
+  Scenario: Patch a missing collection
+    Given the service is running
+    When I send a PATCH request to "/api/v1/evaluations/collections/missing-collection" with body "file:/collection_patch.json"
+    Then the response code should be 404
+
+  Scenario: Run a collection
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection.json"
+    Then the response code should be 201
+    When I send a POST request to "/api/v1/evaluations/collections/{id}/run" with body "file:/collection_run.json"
+    Then the response code should be 202
+    And the response should contain the value "toxicity" at path "$.benchmarks[0].id"
+    When I send a GET request to "/api/v1/evaluations/jobs/{id}"
+    Then the response code should be 200
+
+  Scenario: Run a collection with an unknown provider
+    Given the service is running
+    When I send a POST request to "/api/v1/evaluations/collections" with body "file:/collection_unknown_provider.json"
+    Then the response code should be 201
+    When I send a POST request to "/api/v1/evaluations/collections/{id}/run" with body "file:/collection_run.json"
+    Then the response code should be 422
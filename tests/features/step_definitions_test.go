@@ -186,7 +186,7 @@ func (a *apiFeature) startLocalServer(port int) error {
 		return logError(fmt.Errorf("failed to create runtime: %w", err))
 	}
 
-	a.server, err = server.NewServer(logger, serviceConfig, providerConfigs, storage, validate, runtime, mlflow.NewMLFlowClient())
+	a.server, err = server.NewServer(logger, serviceConfig, providerConfigs, storage, validate, runtime, mlflow.NewMLFlowClient(), nil)
 	if err != nil {
 		return err
 	}
@@ -349,8 +349,8 @@ func extractId(body []byte) (string, error) {
 	return "", nil
 }
 
-func extractIdFromPath(path string) string {
-	if _, after, found := strings.Cut(path, "/api/v1/evaluations/jobs/"); found {
+func extractIdFromPath(path string, prefix string) string {
+	if _, after, found := strings.Cut(path, prefix); found {
 		if after != "" {
 			if id, _, found := strings.Cut(after, "/"); found {
 				return id
@@ -367,7 +367,19 @@ func extractIdFromPath(path string) string {
 // firstPathSegment matches the first path segment after /api/v1/
 var firstPathSegment = regexp.MustCompile(`^.*/api/v1/([^/]+).*$`)
 
+// collectionsPath matches the collections endpoints that live under /api/v1/evaluations/
+var collectionsPath = regexp.MustCompile(`^.*/api/v1/evaluations/collections(/.*)?$`)
+
+// collectionRunPath matches the endpoint that creates an evaluation job from a collection
+var collectionRunPath = regexp.MustCompile(`^.*/api/v1/evaluations/collections/[^/]+/run$`)
+
 func getAssetName(path string) (string, error) {
+	if collectionRunPath.MatchString(path) {
+		return "evaluations", nil
+	}
+	if collectionsPath.MatchString(path) {
+		return "collections", nil
+	}
 	if matches := firstPathSegment.FindStringSubmatch(path); len(matches) >= 2 {
 		return matches[1], nil
 	}
@@ -453,14 +465,14 @@ func (tc *scenarioConfig) iSendARequestToWithBody(method, path, body string) err
 
 	logDebug("Response status %d for %s\n", tc.response.StatusCode, endpoint)
 
-	// this is just for a create evaluation job request
-	if method == http.MethodPost && tc.response.StatusCode == http.StatusAccepted {
+	// this is just for a create evaluation job or collection request
+	if method == http.MethodPost && (tc.response.StatusCode == http.StatusAccepted || tc.response.StatusCode == http.StatusCreated) {
 		assetName, err := getAssetName(endpoint)
 		if err != nil {
 			return err
 		}
 		switch assetName {
-		case "evaluations":
+		case "evaluations", "collections":
 			tc.lastId, err = extractId(tc.body)
 			if err != nil {
 				return err
@@ -481,7 +493,13 @@ func (tc *scenarioConfig) iSendARequestToWithBody(method, path, body string) err
 		}
 		switch assetName {
 		case "evaluations":
-			id := extractIdFromPath(endpoint)
+			id := extractIdFromPath(endpoint, "/api/v1/evaluations/jobs/")
+			if id == "" {
+				return logError(fmt.Errorf("no ID found in path %s", endpoint))
+			}
+			tc.removeAsset(assetName, id)
+		case "collections":
+			id := extractIdFromPath(endpoint, "/api/v1/evaluations/collections/")
 			if id == "" {
 				return logError(fmt.Errorf("no ID found in path %s", endpoint))
 			}
@@ -688,6 +706,8 @@ func (tc *scenarioConfig) assetCleanup(ctx context.Context, sc *godog.Scenario,
 		switch assetName {
 		case "evaluations":
 			url = "evaluations/jobs"
+		case "collections":
+			url = "evaluations/collections"
 		}
 		ids := slices.Clone(ids)
 		for _, id := range ids {
@@ -758,6 +778,9 @@ func checkRegexes() {
 		{"/api/v1/evaluations/jobs/{id}/update", "evaluations"},
 		{"/api/v1/collections", "collections"},
 		{"/api/v1/collections/{id}", "collections"},
+		{"/api/v1/evaluations/collections", "collections"},
+		{"/api/v1/evaluations/collections/{id}", "collections"},
+		{"/api/v1/evaluations/collections/{id}/run", "evaluations"},
 	}
 	for _, path := range paths {
 		name, err := getAssetName(path[0])